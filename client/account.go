@@ -1,7 +1,10 @@
 package client
 
 import (
+	"fmt"
+
 	"github.com/rrossilli/glow/model"
+	"github.com/rrossilli/glow/signer"
 	"github.com/rrossilli/glow/tmp"
 	"github.com/rrossilli/glow/util"
 
@@ -37,36 +40,159 @@ func (c *GlowClient) CreateDisposableAccount() (*model.Account, error) {
 	return acct, err
 }
 
-// Create a new account on chain
+// Create a new account on chain with a single full-weight key. The key uses
+// the private key's signature algorithm and hashAlgo, if given, or else
+// model.DEFAULT_HASH_ALGO, as for keys of CreateAccountWithKeys.
 func (c *GlowClient) CreateAccount(
 	privKey crypto.PrivateKey,
+	hashAlgo ...crypto.HashAlgorithm,
 ) (*model.Account, error) {
+	k := model.AccountKey{
+		PrivateKey: privKey.String(),
+		SigAlgo:    privKey.Algorithm().String(),
+		Weight:     1000,
+	}
+	if len(hashAlgo) > 0 {
+		k.HashAlgo = hashAlgo[0].String()
+	}
+	return c.CreateAccountWithKeys(k)
+}
+
+// CreateAccountWithSigner creates an account on chain with a single
+// full-weight key: the public key of s, which the account then signs with.
+func (c *GlowClient) CreateAccountWithSigner(s signer.Signer) (*model.Account, error) {
+	address, err := c.createAccount(s.PublicKey(), s.HashAlgorithm())
+	if err != nil {
+		return nil, err
+	}
+
+	a := model.NewUnqualifiedAccount(address.String()).WithSigner(s)
+	a.Key.SigAlgo = s.PublicKey().Algorithm().String()
+	a.Key.HashAlgo = s.HashAlgorithm().String()
+
+	return &a, nil
+}
+
+// createAccount creates an account with a single full-weight key and returns its address.
+func (c *GlowClient) createAccount(
+	pubKey crypto.PublicKey,
+	hashAlgo crypto.HashAlgorithm,
+) (cadence.Address, error) {
+	args, err := keyArgs(pubKey, 1000, pubKey.Algorithm(), hashAlgo)
+	if err != nil {
+		return cadence.Address{}, err
+	}
+
 	svcAcct := c.FlowJSON.ServiceAccount(c.network.Name)
 	txRes, err := c.NewTx(
 		[]byte(tmp.TX_CREATE_ACCOUNT),
 		svcAcct,
-		cadence.String(util.RemoveHexPrefix(privKey.PublicKey().String())),
+		args...,
+	).SignAndSend()
+	if err != nil {
+		return cadence.Address{}, err
+	}
+
+	return createdAddress(txRes), nil
+}
+
+// CreateAccountWithKeys creates an account with the given keys, indexed in
+// order. Each key needs a hex PrivateKey, a Signer or, for keys held by
+// another party, a hex PublicKey, and a Weight; algorithms are those of the
+// Signer or default to model.DEFAULT_SIG_ALGO and model.DEFAULT_HASH_ALGO.
+// The account proposes and signs with its first key that can sign unless
+// told otherwise with WithKeyIndex or WithSigningKeys.
+func (c *GlowClient) CreateAccountWithKeys(keys ...model.AccountKey) (*model.Account, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("create account: no keys")
+	}
+
+	var pubKeys, sigAlgos, hashAlgos, weights []cadence.Value
+	var created []model.AccountKey
+	for i, k := range keys {
+		pubKey, sigAlgo, hashAlgo, err := c.keyPublicKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("create account: key %d: %w", i, err)
+		}
+		args, err := keyArgs(pubKey, k.Weight, sigAlgo, hashAlgo)
+		if err != nil {
+			return nil, fmt.Errorf("create account: key %d: %w", i, err)
+		}
+
+		pubKeys = append(pubKeys, args[0])
+		sigAlgos = append(sigAlgos, args[1])
+		hashAlgos = append(hashAlgos, args[2])
+		weights = append(weights, args[3])
+
+		k.Index = i
+		k.SigAlgo = sigAlgo.String()
+		k.HashAlgo = hashAlgo.String()
+		created = append(created, k)
+	}
+
+	txRes, err := c.NewTx(
+		[]byte(tmp.TX_CREATE_ACCOUNT_WITH_KEYS),
+		c.FlowJSON.ServiceAccount(c.network.Name),
+		cadence.NewArray(pubKeys),
+		cadence.NewArray(sigAlgos),
+		cadence.NewArray(hashAlgos),
+		cadence.NewArray(weights),
 	).SignAndSend()
 	if err != nil {
 		return nil, err
 	}
 
-	// fetch the address from the created account
-	var address flow.Address
-	if txRes.Status == flow.TransactionStatusSealed {
-		for _, event := range txRes.Events {
-			if event.Type == flow.EventAccountCreated {
-				accountCreatedEvent := flow.AccountCreatedEvent(event)
-				address = accountCreatedEvent.Address()
-			}
+	key := created[0]
+	for _, k := range created {
+		if k.PrivateKey != "" || k.Signer != nil {
+			key = k
+			break
 		}
 	}
-	addrCdc := cadence.Address(address)
 
-	a := model.NewAccount(
-		addrCdc.String(),
-		privKey.String(),
-	)
+	return &model.Account{
+		Address: createdAddress(txRes).String(),
+		PrivKey: key.PrivateKey,
+		Key:     key,
+		Keys:    created,
+	}, nil
+}
+
+// keyPublicKey returns the public key and algorithms of a key being added
+// to an account, from its Signer if set, its hex public key if set, or else
+// its hex private key.
+func (c *GlowClient) keyPublicKey(k model.AccountKey) (crypto.PublicKey, crypto.SignatureAlgorithm, crypto.HashAlgorithm, error) {
+	if k.Signer != nil {
+		pubKey := k.Signer.PublicKey()
+		return pubKey, pubKey.Algorithm(), k.Signer.HashAlgorithm(), nil
+	}
 
-	return &a, nil
+	sigAlgo, hashAlgo, err := keyAlgorithms(k)
+	if err != nil {
+		return nil, sigAlgo, hashAlgo, err
+	}
+	if k.PublicKey != "" {
+		pubKey, err := crypto.DecodePublicKeyHex(sigAlgo, util.RemoveHexPrefix(k.PublicKey))
+		if err != nil {
+			return nil, sigAlgo, hashAlgo, err
+		}
+		return pubKey, sigAlgo, hashAlgo, nil
+	}
+	privKey, err := crypto.DecodePrivateKeyHex(sigAlgo, util.RemoveHexPrefix(k.PrivateKey))
+	if err != nil {
+		return nil, sigAlgo, hashAlgo, err
+	}
+	return privKey.PublicKey(), sigAlgo, hashAlgo, nil
+}
+
+// createdAddress returns the address of the account created by a transaction.
+func createdAddress(txRes *TxResult) cadence.Address {
+	var address flow.Address
+	if txRes.Status == flow.TransactionStatusSealed {
+		for _, event := range txRes.Events(flow.EventAccountCreated) {
+			accountCreatedEvent := flow.AccountCreatedEvent(event.Event)
+			address = accountCreatedEvent.Address()
+		}
+	}
+	return cadence.Address(address)
 }
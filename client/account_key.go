@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/rrossilli/glow/model"
+	"github.com/rrossilli/glow/tmp"
+	"github.com/rrossilli/glow/util"
+)
+
+// AddKey adds a public key to the account on chain, signing with the
+// account, and records it in acct. Returns the index of the new key. The
+// recorded key has no private key; set its PrivateKey to sign with it.
+func (c *GlowClient) AddKey(
+	acct *model.Account,
+	pubKey crypto.PublicKey,
+	weight int,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (int, error) {
+	args, err := keyArgs(pubKey, weight, sigAlgo, hashAlgo)
+	if err != nil {
+		return 0, &AccountError{Account: acct.Address, Err: err}
+	}
+
+	_, err = c.NewTx([]byte(tmp.TX_ACCOUNT_KEY_ADD), *acct, args...).SignAndSend()
+	if err != nil {
+		return 0, &AccountError{Account: acct.Address, Err: err}
+	}
+
+	index, err := c.lastKeyIndex(acct)
+	if err != nil {
+		return 0, err
+	}
+	acct.AddKey(model.AccountKey{
+		Index:     index,
+		SigAlgo:   sigAlgo.String(),
+		HashAlgo:  hashAlgo.String(),
+		Weight:    weight,
+		PublicKey: util.RemoveHexPrefix(pubKey.String()),
+	})
+
+	return index, nil
+}
+
+// RevokeKey revokes the key at index on chain, signing with the account,
+// and records the revocation in acct.
+func (c *GlowClient) RevokeKey(acct *model.Account, index int) error {
+	_, err := c.NewTx(
+		[]byte(tmp.TX_ACCOUNT_KEY_REVOKE),
+		*acct,
+		cadence.NewInt(index),
+	).SignAndSend()
+	if err != nil {
+		return &AccountError{Account: acct.Address, Err: err}
+	}
+
+	acct.RevokeKey(index)
+	return nil
+}
+
+// RotateKey replaces the account's current key with a newly generated one
+// of the same algorithms and weight, revoking the old key in the same
+// transaction. acct signs with the new key afterwards.
+func (c *GlowClient) RotateKey(acct *model.Account) error {
+	onChain, err := c.FlowKit.GetAccount(context.Background(), acct.FlowAddress())
+	if err != nil {
+		return &AccountError{Account: acct.Address, Err: err}
+	}
+	if acct.Key.Index >= len(onChain.Keys) {
+		return &AccountError{Account: acct.Address, Err: fmt.Errorf("no key at index %d", acct.Key.Index)}
+	}
+	old := onChain.Keys[acct.Key.Index]
+
+	seed := make([]byte, crypto.MinSeedLength)
+	if _, err := rand.Read(seed); err != nil {
+		return err
+	}
+	privKey, err := crypto.GeneratePrivateKey(old.SigAlgo, seed)
+	if err != nil {
+		return &AccountError{Account: acct.Address, Err: err}
+	}
+
+	args, err := keyArgs(privKey.PublicKey(), old.Weight, old.SigAlgo, old.HashAlgo)
+	if err != nil {
+		return &AccountError{Account: acct.Address, Err: err}
+	}
+	args = append(args, cadence.NewInt(acct.Key.Index))
+
+	_, err = c.NewTx([]byte(tmp.TX_ACCOUNT_KEY_ROTATE), *acct, args...).SignAndSend()
+	if err != nil {
+		return &AccountError{Account: acct.Address, Err: err}
+	}
+
+	index, err := c.lastKeyIndex(acct)
+	if err != nil {
+		return err
+	}
+	key := model.AccountKey{
+		Index:      index,
+		SigAlgo:    old.SigAlgo.String(),
+		HashAlgo:   old.HashAlgo.String(),
+		Weight:     old.Weight,
+		PrivateKey: util.RemoveHexPrefix(privKey.String()),
+	}
+	acct.RevokeKey(acct.Key.Index)
+	acct.AddKey(key)
+	*acct = acct.WithKeyIndex(index)
+
+	return nil
+}
+
+// keyArgs returns the key arguments of the key management transactions.
+func keyArgs(
+	pubKey crypto.PublicKey,
+	weight int,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) ([]cadence.Value, error) {
+	sig, ok := cadenceSigAlgos[sigAlgo]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signature algorithm %s", sigAlgo)
+	}
+	hash, ok := cadenceHashAlgos[hashAlgo]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm %s", hashAlgo)
+	}
+	w, err := cadence.NewUFix64(fmt.Sprintf("%d.0", weight))
+	if err != nil {
+		return nil, err
+	}
+
+	return []cadence.Value{
+		cadence.String(util.RemoveHexPrefix(pubKey.String())),
+		cadence.UInt8(sig),
+		cadence.UInt8(hash),
+		w,
+	}, nil
+}
+
+// lastKeyIndex returns the index of the account's most recently added key.
+func (c *GlowClient) lastKeyIndex(acct *model.Account) (int, error) {
+	onChain, err := c.FlowKit.GetAccount(context.Background(), acct.FlowAddress())
+	if err != nil {
+		return 0, &AccountError{Account: acct.Address, Err: err}
+	}
+	return len(onChain.Keys) - 1, nil
+}
@@ -0,0 +1,514 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/rrossilli/glow/model"
+	"github.com/rrossilli/glow/util"
+)
+
+// CADENCE_TAG names the Cadence field a Go struct field is converted to by
+// ArgsGo, e.g. `cadence:"royaltyCut"`. Fields without it match the Cadence
+// field of the same name, ignoring case.
+const CADENCE_TAG = "cadence"
+
+// integer bounds of Cadence's fixed-size integer types, by name
+var intBounds = map[string][2]*big.Int{}
+
+func init() {
+	for _, bits := range []uint{8, 16, 32, 64, 128, 256} {
+		max := new(big.Int).Lsh(big.NewInt(1), bits)
+		umax := new(big.Int).Sub(max, big.NewInt(1))
+		half := new(big.Int).Rsh(max, 1)
+		n := strconv.Itoa(int(bits))
+
+		intBounds["Int"+n] = [2]*big.Int{new(big.Int).Neg(half), new(big.Int).Sub(half, big.NewInt(1))}
+		intBounds["UInt"+n] = [2]*big.Int{big.NewInt(0), umax}
+		intBounds["Word"+n] = [2]*big.Int{big.NewInt(0), umax}
+	}
+	intBounds["Int"] = [2]*big.Int{nil, nil}
+	intBounds["UInt"] = [2]*big.Int{big.NewInt(0), nil}
+}
+
+// ArgsGo sets the transaction's arguments from Go values, converted to the
+// parameter types declared in its code; see GlowClient.ConvertArgs.
+// Conversion errors are returned by Build and Sign.
+func (t *Tx) ArgsGo(args ...interface{}) *Tx {
+	t.script.Args, t.argsErr = t.client.ConvertArgs(t.script.Code, args...)
+	return t
+}
+
+// ArgsGo sets the script's arguments from Go values, converted to the
+// parameter types declared in its code; see GlowClient.ConvertArgs.
+// Conversion errors are returned by Exec and ExecWithQuery.
+func (sc *Sc) ArgsGo(args ...interface{}) *Sc {
+	sc.script.Args, sc.argsErr = sc.client.ConvertArgs(sc.script.Code, args...)
+	return sc
+}
+
+// ConvertArgs converts Go values to the types of the parameters declared by
+// the transaction or script code, in order:
+//
+//   - Address: flow.Address, model.Account, *model.Account or a hex string
+//   - String and Character: string
+//   - Bool: bool
+//   - integers: any Go integer, *big.Int or a decimal string, range checked
+//   - Fix64 and UFix64: floats, integers or decimal strings such as "1.5"
+//   - paths: strings such as "/storage/vault"
+//   - optionals: nil, a pointer, or a value of the inner type
+//   - arrays: slices and arrays; dictionaries: maps
+//   - structs of contracts in flow.json: Go structs, fields matched by name
+//     or CADENCE_TAG
+//
+// A cadence.Value is passed as is, at any depth. Each failed conversion is
+// returned as an *ArgError.
+func (c *GlowClient) ConvertArgs(code []byte, args ...interface{}) ([]cadence.Value, error) {
+	params, err := declaredParams(code)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != len(params) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(params), len(args))
+	}
+
+	values := make([]cadence.Value, len(args))
+	for i, p := range params {
+		v, err := c.convertArg(args[i], p.TypeAnnotation.Type, "")
+		if err != nil {
+			return nil, &ArgError{
+				Index: i,
+				Param: p.Identifier.Identifier,
+				Type:  p.TypeAnnotation.Type.String(),
+				Err:   err,
+			}
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// declaredParams returns the parameters of a transaction or script's main function.
+func declaredParams(code []byte) ([]*ast.Parameter, error) {
+	program, err := parseCadence(code)
+	if err != nil {
+		return nil, err
+	}
+
+	if txs := program.TransactionDeclarations(); len(txs) > 0 {
+		if txs[0].ParameterList == nil {
+			return nil, nil
+		}
+		return txs[0].ParameterList.Parameters, nil
+	}
+	for _, f := range program.FunctionDeclarations() {
+		if f.Identifier.Identifier == "main" {
+			return f.ParameterList.Parameters, nil
+		}
+	}
+	return nil, fmt.Errorf("no transaction or main function declared")
+}
+
+func parseCadence(code []byte) (*ast.Program, error) {
+	program, err := model.ParseCadence(code)
+	if err != nil {
+		return nil, fmt.Errorf("parse Cadence: %w", err)
+	}
+	return program, nil
+}
+
+// convertArg converts v to the Cadence type t. Unqualified composite types
+// are looked up in contract, the contract declaring the enclosing struct.
+func (c *GlowClient) convertArg(v interface{}, t ast.Type, contract string) (cadence.Value, error) {
+	if cv, ok := v.(cadence.Value); ok {
+		return cv, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if opt, ok := t.(*ast.OptionalType); ok {
+		if isNil(rv) {
+			return cadence.NewOptional(nil), nil
+		}
+		if rv.Kind() == reflect.Pointer {
+			v = rv.Elem().Interface()
+		}
+		inner, err := c.convertArg(v, opt.Type, contract)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewOptional(inner), nil
+	}
+
+	if isNil(rv) {
+		return nil, fmt.Errorf("nil is not a %s", t)
+	}
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+		v = rv.Interface()
+	}
+
+	switch t := t.(type) {
+	case *ast.VariableSizedType:
+		return c.convertArray(rv, t.Type, -1, contract)
+
+	case *ast.ConstantSizedType:
+		return c.convertArray(rv, t.Type, int(t.Size.Value.Int64()), contract)
+
+	case *ast.DictionaryType:
+		return c.convertDictionary(rv, t, contract)
+
+	case *ast.NominalType:
+		if len(t.NestedIdentifiers) == 0 {
+			if cv, ok, err := convertSimple(v, t.Identifier.Identifier); ok {
+				return cv, err
+			}
+		}
+		return c.convertStruct(rv, t, contract)
+	}
+
+	return nil, fmt.Errorf("cannot convert %T to %s; pass a cadence.Value", v, t)
+}
+
+func (c *GlowClient) convertArray(rv reflect.Value, elem ast.Type, size int, contract string) (cadence.Value, error) {
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot convert %s to an array", rv.Type())
+	}
+	if size >= 0 && rv.Len() != size {
+		return nil, fmt.Errorf("expected %d elements, got %d", size, rv.Len())
+	}
+
+	values := make([]cadence.Value, rv.Len())
+	for i := range values {
+		v, err := c.convertArg(rv.Index(i).Interface(), elem, contract)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		values[i] = v
+	}
+	return cadence.NewArray(values), nil
+}
+
+func (c *GlowClient) convertDictionary(rv reflect.Value, t *ast.DictionaryType, contract string) (cadence.Value, error) {
+	if rv.Kind() != reflect.Map {
+		return nil, fmt.Errorf("cannot convert %s to a dictionary", rv.Type())
+	}
+
+	pairs := make([]cadence.KeyValuePair, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		k, err := c.convertArg(iter.Key().Interface(), t.KeyType, contract)
+		if err != nil {
+			return nil, fmt.Errorf("key %v: %w", iter.Key(), err)
+		}
+		v, err := c.convertArg(iter.Value().Interface(), t.ValueType, contract)
+		if err != nil {
+			return nil, fmt.Errorf("value of key %v: %w", iter.Key(), err)
+		}
+		pairs = append(pairs, cadence.KeyValuePair{Key: k, Value: v})
+	}
+
+	// maps are unordered; sort for reproducible transactions
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Key.String() < pairs[j].Key.String()
+	})
+	return cadence.NewDictionary(pairs), nil
+}
+
+// convertStruct converts a Go struct to a struct declared by a contract in flow.json.
+func (c *GlowClient) convertStruct(rv reflect.Value, t *ast.NominalType, contract string) (cadence.Value, error) {
+	path := []string{t.Identifier.Identifier}
+	for _, id := range t.NestedIdentifiers {
+		path = append(path, id.Identifier)
+	}
+	if len(path) == 1 {
+		if contract == "" {
+			return nil, fmt.Errorf("cannot convert %s to %s; pass a cadence.Value", rv.Type(), t)
+		}
+		path = append([]string{contract}, path...)
+	}
+	contract = path[0]
+	qualified := strings.Join(path, ".")
+
+	decl, err := c.compositeDeclaration(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", qualified, err)
+	}
+	if decl.Kind() != common.CompositeKindStructure {
+		return nil, fmt.Errorf("%s is a %s; only structs can be converted", qualified, decl.Kind().Name())
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot convert %s to %s", rv.Type(), qualified)
+	}
+
+	var fields []cadence.Field
+	var values []cadence.Value
+	for _, f := range decl.Members.Fields() {
+		name := f.Identifier.Identifier
+		index, ok := goField(rv.Type(), name)
+		if !ok {
+			return nil, fmt.Errorf("%s has no field for %s.%s", rv.Type(), qualified, name)
+		}
+		v, err := c.convertArg(rv.Field(index).Interface(), f.TypeAnnotation.Type, contract)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		fields = append(fields, cadence.Field{Identifier: name})
+		values = append(values, v)
+	}
+
+	address := c.FlowJSON.ContractAddress(contract, c.network.Name)
+	if address == "" {
+		return nil, fmt.Errorf("%s: no address for contract %s", qualified, contract)
+	}
+	location := common.NewAddressLocation(nil, common.Address(flow.HexToAddress(address)), contract)
+
+	return cadence.NewStruct(values).WithType(&cadence.StructType{
+		Location:            location,
+		QualifiedIdentifier: qualified,
+		Fields:              fields,
+	}), nil
+}
+
+// compositeDeclaration finds the declaration of the composite type at path,
+// e.g. [MetadataViews Royalty], in the source of its contract.
+func (c *GlowClient) compositeDeclaration(path []string) (*ast.CompositeDeclaration, error) {
+	contract, ok := c.FlowJSON.Contracts()[path[0]]
+	if !ok {
+		return nil, fmt.Errorf("contract %s: %w", path[0], ErrContractNotFound)
+	}
+	code, err := c.CadenceFromFile(contract.Source)
+	if err != nil {
+		return nil, &CadenceFileError{Path: contract.Source, Err: err}
+	}
+	program, err := parseCadence([]byte(code))
+	if err != nil {
+		return nil, &ContractError{Contract: path[0], Err: err}
+	}
+
+	composites := program.CompositeDeclarations()
+	var decl *ast.CompositeDeclaration
+	for _, id := range path {
+		decl = nil
+		for _, d := range composites {
+			if d.Identifier.Identifier == id {
+				decl = d
+				break
+			}
+		}
+		if decl == nil {
+			return nil, fmt.Errorf("type %s not declared in contract %s", strings.Join(path, "."), path[0])
+		}
+		composites = decl.Members.Composites()
+	}
+	return decl, nil
+}
+
+// convertSimple converts v to the Cadence type named typ. ok is false if
+// typ is not a simple type.
+func convertSimple(v interface{}, typ string) (cadence.Value, bool, error) {
+	mismatch := func() (cadence.Value, bool, error) {
+		return nil, true, fmt.Errorf("cannot convert %T to %s", v, typ)
+	}
+
+	switch typ {
+	case "Address":
+		switch a := v.(type) {
+		case flow.Address:
+			return cadence.Address(a), true, nil
+		case model.Account:
+			return a.CadenceAddress(), true, nil
+		case string:
+			hex := util.RemoveHexPrefix(a)
+			if len(hex) == 0 || len(hex) > 2*flow.AddressLength || strings.Trim(hex, "0123456789abcdefABCDEF") != "" {
+				return nil, true, fmt.Errorf("invalid address %q", a)
+			}
+			return cadence.Address(flow.HexToAddress(hex)), true, nil
+		}
+		return mismatch()
+
+	case "String", "Character":
+		s, ok := v.(string)
+		if !ok {
+			return mismatch()
+		}
+		if typ == "Character" {
+			ch, err := cadence.NewCharacter(s)
+			return ch, true, err
+		}
+		str, err := cadence.NewString(s)
+		return str, true, err
+
+	case "Bool":
+		b, ok := v.(bool)
+		if !ok {
+			return mismatch()
+		}
+		return cadence.NewBool(b), true, nil
+
+	case "Fix64", "UFix64":
+		var s string
+		rv := reflect.ValueOf(v)
+		switch {
+		case rv.CanFloat():
+			// Cadence has 8 decimal places; rounding drops float noise
+			s = strings.TrimRight(strconv.FormatFloat(rv.Float(), 'f', 8, 64), "0")
+		case rv.CanInt():
+			s = strconv.FormatInt(rv.Int(), 10)
+		case rv.CanUint():
+			s = strconv.FormatUint(rv.Uint(), 10)
+		case rv.Kind() == reflect.String:
+			s = rv.String()
+		default:
+			return mismatch()
+		}
+		if strings.HasSuffix(s, ".") {
+			s += "0"
+		} else if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		if typ == "Fix64" {
+			f, err := cadence.NewFix64(s)
+			return f, true, err
+		}
+		if strings.HasPrefix(s, "-") {
+			return nil, true, fmt.Errorf("%s is negative", s)
+		}
+		f, err := cadence.NewUFix64(s)
+		return f, true, err
+
+	case "Path", "StoragePath", "PublicPath", "PrivatePath", "CapabilityPath":
+		s, ok := v.(string)
+		if !ok {
+			return mismatch()
+		}
+		parts := strings.Split(s, "/")
+		if len(parts) != 3 || parts[0] != "" || parts[2] == "" {
+			return nil, true, fmt.Errorf("invalid path %q", s)
+		}
+		domain := common.PathDomainFromIdentifier(parts[1])
+		allowed := map[string][]common.PathDomain{
+			"StoragePath":    {common.PathDomainStorage},
+			"PublicPath":     {common.PathDomainPublic},
+			"PrivatePath":    {common.PathDomainPrivate},
+			"CapabilityPath": {common.PathDomainPublic, common.PathDomainPrivate},
+		}
+		if domain == common.PathDomainUnknown {
+			return nil, true, fmt.Errorf("invalid path %q", s)
+		}
+		if domains, ok := allowed[typ]; ok && !containsDomain(domains, domain) {
+			return nil, true, fmt.Errorf("path %q is not a %s", s, typ)
+		}
+		return cadence.Path{Domain: domain, Identifier: parts[2]}, true, nil
+	}
+
+	bounds, ok := intBounds[typ]
+	if !ok {
+		return nil, false, nil
+	}
+	n, ok := bigInt(v)
+	if !ok {
+		return mismatch()
+	}
+	if (bounds[0] != nil && n.Cmp(bounds[0]) < 0) || (bounds[1] != nil && n.Cmp(bounds[1]) > 0) {
+		return nil, true, fmt.Errorf("%s is out of range for %s", n, typ)
+	}
+	cv, err := newInteger(typ, n)
+	return cv, true, err
+}
+
+// bigInt returns the value of a Go integer, *big.Int or decimal string.
+func bigInt(v interface{}) (*big.Int, bool) {
+	switch n := v.(type) {
+	case big.Int:
+		return &n, true
+	case *big.Int:
+		return n, n != nil
+	case string:
+		return new(big.Int).SetString(n, 10)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanInt():
+		return big.NewInt(rv.Int()), true
+	case rv.CanUint():
+		return new(big.Int).SetUint64(rv.Uint()), true
+	}
+	return nil, false
+}
+
+// newInteger returns the Cadence integer of type typ with value n, which is in range.
+func newInteger(typ string, n *big.Int) (cadence.Value, error) {
+	switch typ {
+	case "Int":
+		return cadence.NewIntFromBig(n), nil
+	case "Int8":
+		return cadence.NewInt8(int8(n.Int64())), nil
+	case "Int16":
+		return cadence.NewInt16(int16(n.Int64())), nil
+	case "Int32":
+		return cadence.NewInt32(int32(n.Int64())), nil
+	case "Int64":
+		return cadence.NewInt64(n.Int64()), nil
+	case "Int128":
+		return cadence.NewInt128FromBig(n)
+	case "Int256":
+		return cadence.NewInt256FromBig(n)
+	case "UInt":
+		return cadence.NewUIntFromBig(n)
+	case "UInt8":
+		return cadence.NewUInt8(uint8(n.Uint64())), nil
+	case "UInt16":
+		return cadence.NewUInt16(uint16(n.Uint64())), nil
+	case "UInt32":
+		return cadence.NewUInt32(uint32(n.Uint64())), nil
+	case "UInt64":
+		return cadence.NewUInt64(n.Uint64()), nil
+	case "UInt128":
+		return cadence.NewUInt128FromBig(n)
+	case "UInt256":
+		return cadence.NewUInt256FromBig(n)
+	case "Word8":
+		return cadence.NewWord8(uint8(n.Uint64())), nil
+	case "Word16":
+		return cadence.NewWord16(uint16(n.Uint64())), nil
+	case "Word32":
+		return cadence.NewWord32(uint32(n.Uint64())), nil
+	case "Word64":
+		return cadence.NewWord64(n.Uint64()), nil
+	case "Word128":
+		return cadence.NewWord128FromBig(n)
+	case "Word256":
+		return cadence.NewWord256FromBig(n)
+	}
+	return nil, fmt.Errorf("unsupported integer type %s", typ)
+}
+
+func containsDomain(domains []common.PathDomain, d common.PathDomain) bool {
+	for _, x := range domains {
+		if x == d {
+			return true
+		}
+	}
+	return false
+}
+
+func isNil(rv reflect.Value) bool {
+	if !rv.IsValid() {
+		return true
+	}
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/rrossilli/glow/model"
 	"github.com/rrossilli/glow/util"
 )
 
@@ -17,56 +18,72 @@ func (c *GlowClient) CadenceFromFile(file string) (string, error) {
 		return "", err
 	}
 
-	editedCdc := c.replaceImportAddresses(string(cdc))
-
-	return editedCdc, nil
+	return c.replaceImportAddresses(string(cdc))
 }
 
-// Replace "0x" imports and file import paths in cadence with addresses from specified flow.json
-func (c *GlowClient) replaceImportAddresses(cdc string) string {
-
-	// Replace file import paths
-	lines := strings.Split(string(cdc), "\n")
-	for i, line := range lines {
-		lines[i] = replaceFileImportPath(line)
+// Replace the locations of import declarations in cadence with the addresses
+// of their contracts in the specified flow.json:
+//
+//	import A from 0xA           -> import A from 0x01
+//	import A, B from "./A.cdc"  -> import A, B from 0x01
+//	import "A"                  -> import A from 0x01
+//
+// Contracts resolve to their alias on the active network, or to the account
+// they are deployed to. Address imports and everything outside import
+// declarations are left as written. Imports that do not resolve are left
+// too, and returned in an *UnresolvedImportsError with the code.
+func (c *GlowClient) replaceImportAddresses(cdc string) (string, error) {
+	var b strings.Builder
+	var unresolved []model.Import
+	last := 0
+	for _, imp := range c.FlowJSON.ParseImports(cdc) {
+		if imp.Kind == model.IMPORT_ADDRESS {
+			continue
+		}
+		decl, ok := c.resolveImport(imp)
+		if !ok {
+			unresolved = append(unresolved, imp)
+			continue
+		}
+		b.WriteString(cdc[last:imp.Start])
+		b.WriteString(decl)
+		last = imp.End
 	}
-	newCdc := strings.Join(lines, "\n")
+	b.WriteString(cdc[last:])
 
-	// map to contract names
-	contracts := c.FlowJSON.Contracts()
-	var contractNames []string
-	for cn := range contracts {
-		contractNames = append(contractNames, cn)
+	if len(unresolved) > 0 {
+		return b.String(), &UnresolvedImportsError{Network: c.network.Name, Imports: unresolved}
 	}
+	return b.String(), nil
+}
 
-	// sort contract names by length (longest first) so that we replace the longest
-	// contract names first. This is to avoid replacing a contract name that is a
-	// substring of another contract name
-	contractNamesSorted := util.SortStringsByCharacterLength(contractNames, false)
-
-	// replace 0x imports
-	for _, key := range contractNamesSorted {
-		co := c.FlowJSON.Contract(key)
-		newCdc = strings.Replace(
-			newCdc,
-			util.PrependHexPrefix(key),
-			co.Address(c.network.Name),
-			-1,
-		)
+// resolveImport returns the declaration importing imp's identifiers from
+// their flow.json addresses, and false if any is not resolved. Identifiers
+// of a file import deployed to different addresses are imported by separate
+// declarations on the same line, keeping line numbers intact.
+func (c *GlowClient) resolveImport(imp model.Import) (string, bool) {
+	contract := func(name string) string { return name }
+	if imp.Kind == model.IMPORT_PLACEHOLDER {
+		contract = func(string) string { return strings.TrimPrefix(imp.Location, "0x") }
 	}
 
-	return newCdc
-}
+	var addrs []string
+	names := map[string][]string{}
+	for _, name := range imp.Names {
+		addr := c.FlowJSON.ContractAddress(contract(name), c.network.Name)
+		if addr == "" {
+			return "", false
+		}
+		addr = util.PrependHexPrefix(addr)
+		if _, ok := names[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+		names[addr] = append(names[addr], name)
+	}
 
-// Replace relative file import path with "0x path"
-// i.e. import NonFungibleToken from "./NonFungibleToken.cdc" as ...from 0xNonFungibleToken
-func replaceFileImportPath(s string) string {
-	if strings.Contains(s, "import") &&
-		strings.Contains(s, "from") &&
-		strings.Contains(s, `.cdc`) {
-		fields := strings.Fields(s)
-		fields[3] = fmt.Sprintf("0x%s", fields[1])
-		return strings.Join(fields, " ")
+	decls := make([]string, len(addrs))
+	for i, addr := range addrs {
+		decls[i] = fmt.Sprintf("import %s from %s", strings.Join(names[addr], ", "), addr)
 	}
-	return s
+	return strings.Join(decls, "; "), len(decls) > 0
 }
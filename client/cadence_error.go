@@ -0,0 +1,92 @@
+package client
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kinds of failed conditions reported by CadenceError.
+const (
+	CONDITION_PRE  = "pre"
+	CONDITION_POST = "post"
+)
+
+// EXECUTION_FAILED precedes the Cadence error in a failed execution's message.
+const EXECUTION_FAILED = "Execution failed:\n"
+
+var (
+	errorCodeRe = regexp.MustCompile(`\[Error Code: (\d+)\]`)
+	locationRe  = regexp.MustCompile(`^\s*--> (\S+):(\d+):(\d+)$`)
+)
+
+// CadenceError is a failed transaction's error, parsed from the message
+// returned by the network.
+type CadenceError struct {
+	Code      int    // innermost FVM error code, e.g. 1101
+	Message   string // Cadence error message, e.g. "panic: missing NFT"
+	Panic     string // message passed to panic, if the error is a panic
+	Condition string // CONDITION_PRE or CONDITION_POST if a condition failed
+	Location  string // where the error occurred: a transaction ID or a contract, e.g. f8d6e0586b0a20c7.ExampleNFT
+	Line      int
+	Column    int
+	Detail    string // the Cadence error with call stack and code excerpts, without the FVM wrapping
+	Err       error  // the error as returned by the network
+}
+
+func (e *CadenceError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CadenceError) Unwrap() error {
+	return e.Err
+}
+
+// NewCadenceError parses a failed transaction's error. Fields that cannot
+// be found in the message are left empty.
+func NewCadenceError(err error) *CadenceError {
+	var ce *CadenceError
+	if errors.As(err, &ce) {
+		return ce
+	}
+
+	msg := err.Error()
+	ce = &CadenceError{Err: err, Detail: strings.TrimSpace(msg)}
+
+	if m := errorCodeRe.FindAllStringSubmatch(msg, -1); m != nil {
+		ce.Code, _ = strconv.Atoi(m[len(m)-1][1])
+	}
+	if i := strings.LastIndex(msg, EXECUTION_FAILED); i >= 0 {
+		ce.Detail = strings.TrimSpace(msg[i+len(EXECUTION_FAILED):])
+	}
+
+	// the call stack comes first, then the error and the location it occurred at
+	lines := strings.Split(ce.Detail, "\n")
+	for i, l := range lines {
+		if !strings.HasPrefix(l, "error: ") {
+			continue
+		}
+		ce.Message = strings.TrimPrefix(l, "error: ")
+		for _, next := range lines[i+1:] {
+			if m := locationRe.FindStringSubmatch(next); m != nil {
+				ce.Location = m[1]
+				ce.Line, _ = strconv.Atoi(m[2])
+				ce.Column, _ = strconv.Atoi(m[3])
+				break
+			}
+		}
+		break
+	}
+
+	switch {
+	case strings.HasPrefix(ce.Message, "panic: "):
+		ce.Panic = strings.TrimPrefix(ce.Message, "panic: ")
+	case strings.HasPrefix(ce.Message, "pre-condition failed"):
+		ce.Condition = CONDITION_PRE
+	case strings.HasPrefix(ce.Message, "post-condition failed"):
+		ce.Condition = CONDITION_POST
+	}
+
+	return ce
+}
@@ -2,19 +2,18 @@ package client
 
 import (
 	"bytes"
-	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"strconv"
+	"sync"
 
-	"github.com/onflow/cadence"
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-emulator/emulator"
+	"github.com/onflow/flow-emulator/storage/sqlite"
 
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/afero"
@@ -22,7 +21,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/rrossilli/glow/model"
-	"github.com/rrossilli/glow/tmp"
+	"github.com/rrossilli/glow/signer"
 )
 
 const (
@@ -128,6 +127,21 @@ type GlowClient struct {
 	SigAlgo  crypto.SignatureAlgorithm
 	SvcAcct  model.Account
 	gasLimit uint64
+
+	deploymentReport DeploymentReport
+
+	emulator    *gateway.EmulatorGateway // set when running the embedded emulator
+	snapshots   map[string]uint64        // snapshot name to block height
+	snapshotsMu sync.Mutex
+	computation *computationLog // set when running the embedded emulator
+
+	signers   map[string]signer.Signer // signers of file, keystore and KMS keys, by key
+	signersMu sync.Mutex
+}
+
+// Returns the report of the contract deployment run during startup.
+func (c *GlowClient) DeploymentReport() DeploymentReport {
+	return c.deploymentReport
 }
 
 // Returns the network configuration.
@@ -158,76 +172,104 @@ func NewGlowClient() *GlowClientBuilder {
 }
 
 // parseFlowJSON loads and unmarshals the flow.json file.
-func parseFlowJSON(file string) (flowJSON model.FlowJSON) {
-	jsonFile, err := os.Open(file)
-	if err != nil {
-		panic(err)
-	}
-	defer jsonFile.Close()
-	byteValue, err := io.ReadAll(jsonFile)
+func parseFlowJSON(file string) (model.FlowJSON, error) {
+	return model.FlowJSON{}.FromFile(file)
+}
+
+// MustStart initializes the GlowClient like StartE, but panics if startup fails.
+func (b *GlowClientBuilder) MustStart() *GlowClient {
+	c, err := b.StartE()
 	if err != nil {
 		panic(err)
 	}
-	var flowJSONData interface{}
-	json.Unmarshal(byteValue, &flowJSONData)
-
-	fmt.Printf("flowJSON: %v\n", flowJSON)
-
-	return flowJSON
+	return c
 }
 
-// Initializes the GlowClient with the configurations set in the builder.
+// Start initializes the GlowClient with the configurations set in the builder.
+//
+// Deprecated: Start panics if startup fails; use StartE, or MustStart.
 func (b *GlowClientBuilder) Start() *GlowClient {
+	return b.MustStart()
+}
+
+// StartE initializes the GlowClient with the configurations set in the builder.
+// Failures are returned as a *StartError naming the failing stage.
+func (b *GlowClientBuilder) StartE() (*GlowClient, error) {
 	logger := output.NewStdoutLogger(b.LogLvl)
 	loader := &afero.Afero{Fs: afero.NewOsFs()}
 
 	fJSONPath := fmt.Sprintf("%s/flow.json", b.Root) // assumes that flow.json is at root
 	state, err := flowkit.Load([]string{fJSONPath}, loader)
 	if err != nil {
-		// logger.Error(fmt.Sprintf("\nFlowkit was unable to load project configuration at path: %s", b.Root))
-		panic(err)
+		return nil, &StartError{Stage: STAGE_LOAD_CONFIG, Err: fmt.Errorf("%s: %w", fJSONPath, err)}
+	}
+
+	flowJSON, err := parseFlowJSON(fJSONPath)
+	if err != nil {
+		return nil, &StartError{Stage: STAGE_PARSE_FLOW_JSON, Err: fmt.Errorf("%s: %w", fJSONPath, err)}
 	}
 
-	flowJSON := parseFlowJSON(fJSONPath)
+	diags := flowJSON.Validate(b.NetworkName)
+	for _, d := range diags {
+		if d.Severity == model.SEVERITY_WARNING {
+			logger.Info(d.String())
+		}
+	}
+	if model.HasErrors(diags) {
+		return nil, &StartError{Stage: STAGE_VALIDATE_CONFIG, Err: &ValidationError{Diagnostics: diags}}
+	}
 
 	network, err := state.Networks().ByName(b.NetworkName)
 	if err != nil {
-		panic(err)
+		return nil, &StartError{Stage: STAGE_RESOLVE_NETWORK, Err: err}
 	}
 
 	logger.Info(fmt.Sprintf("\nGlow Client Starting: Network=%v, InMemory=%v, Root=%v", b.NetworkName, b.InMemory, b.Root))
 
 	var kit *flowkit.Flowkit
 	var gw gateway.Gateway
+	var emulatorGw *gateway.EmulatorGateway
+	var computation *computationLog
 	if b.InMemory {
 		var memlog bytes.Buffer
 		writer := io.Writer(&memlog)
 		emulatorLogger := zerolog.New(writer).Level(zerolog.DebugLevel)
+
+		// sqlite storage supports rolling back to a block height, which backs snapshots
+		store, err := sqlite.New(sqlite.InMemory)
+		if err != nil {
+			return nil, &StartError{Stage: STAGE_CREATE_GATEWAY, Err: err}
+		}
+		computation = newComputationLog()
 		emulatorOpts := []emulator.Option{
 			emulator.WithLogger(emulatorLogger),
+			emulator.WithServerLogger(zerolog.New(computation).Level(zerolog.DebugLevel)),
+			emulator.WithStore(store),
+			emulator.WithTransactionExpiry(EMULATOR_TX_EXPIRY),
 		}
 
 		svcAcct, err := state.EmulatorServiceAccount()
 		if err != nil {
-			panic(err)
+			return nil, &StartError{Stage: STAGE_CREATE_GATEWAY, Err: err}
 		}
 
 		pk, err := svcAcct.Key.PrivateKey()
 		if err != nil {
-			panic(err)
+			return nil, &StartError{Stage: STAGE_CREATE_GATEWAY, Err: &AccountError{Account: svcAcct.Name, Err: err}}
 		}
 
 		emulatorKey := &gateway.EmulatorKey{
 			PublicKey: (*pk).PublicKey(),
-			SigAlgo:   b.SigAlgo,
-			HashAlgo:  b.HashAlgo,
+			SigAlgo:   svcAcct.Key.SigAlgo(),
+			HashAlgo:  svcAcct.Key.HashAlgo(),
 		}
 
-		gw = gateway.NewEmulatorGatewayWithOpts(emulatorKey, gateway.WithLogger(&emulatorLogger), gateway.WithEmulatorOptions(emulatorOpts...))
+		emulatorGw = gateway.NewEmulatorGatewayWithOpts(emulatorKey, gateway.WithLogger(&emulatorLogger), gateway.WithEmulatorOptions(emulatorOpts...))
+		gw = emulatorGw
 	} else {
 		gw, err = gateway.NewGrpcGateway(*network)
 		if err != nil {
-			panic(err)
+			return nil, &StartError{Stage: STAGE_CREATE_GATEWAY, Err: err}
 		}
 
 	}
@@ -245,77 +287,71 @@ func (b *GlowClientBuilder) Start() *GlowClient {
 		SigAlgo:  b.SigAlgo,
 		gasLimit: b.GasLim,
 		SvcAcct:  svcAcct,
+
+		emulator:    emulatorGw,
+		snapshots:   map[string]uint64{},
+		computation: computation,
+		signers:     map[string]signer.Signer{},
 	}
 
 	if b.ShouldCreateAccounts {
-		wrappedClient.createAccounts()
+		err := wrappedClient.createAccounts()
+		if err != nil {
+			return nil, &StartError{Stage: STAGE_CREATE_ACCOUNTS, Err: err}
+		}
 	}
 
 	if b.ShouldDeployContracts {
-		wrappedClient.deployContracts()
+		report, err := wrappedClient.Deploy()
+		wrappedClient.deploymentReport = report
+		if err != nil {
+			return nil, &StartError{Stage: STAGE_DEPLOY_CONTRACTS, Err: err}
+		}
 	}
 
-	return &wrappedClient
+	return &wrappedClient, nil
 }
 
-// Initializes accounts on the Flow network
-func (c *GlowClient) createAccounts() {
+// Initializes accounts on the Flow network. Accounts are created in the
+// order of the emulator's address sequence so each gets its configured address.
+func (c *GlowClient) createAccounts() error {
 	c.Logger.Info("Creating Accounts:")
 
-	accounts := c.FlowJSON.AccountsSorted()
-	for i, a := range accounts {
+	names, err := c.FlowJSON.AccountNamesSorted(c.network.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range names {
+		a := c.FlowJSON.Account(n)
+
 		// skip svc account
-		if i == 0 {
+		if a.FlowAddress() == c.SvcAcct.FlowAddress() {
 			continue
 		}
 
-		acct, err := c.CreateAccount(
-			a.CryptoPrivateKey(),
-		)
+		k, ok := a.KeyAt(a.Key.Index)
+		if !ok {
+			return &AccountError{Account: n, Err: fmt.Errorf("no private key or signer")}
+		}
+		s, err := c.keySigner(a, k)
 		if err != nil {
-			panic(err)
+			return &AccountError{Account: n, Err: err}
 		}
 
-		c.Logger.Info(fmt.Sprintf("Account=%s Created", acct.Address))
-	}
-}
-
-// Deploys smart contracts to accounts on the Flow network
-func (c *GlowClient) deployContracts() {
-	c.Logger.Info("Deploy Contracts:")
-
-	acctNames := c.FlowJSON.AccountNamesSorted(c.network.Name) // sorted list of account names
-
-	fmt.Println("===============")
-	fmt.Println(acctNames)
-	fmt.Println("===============")
-
-	for _, a := range acctNames {
-
-		deployment := c.FlowJSON.Deployment(c.network.Name)
-		fmt.Println("===============")
-		fmt.Println(deployment)
-		fmt.Println("===============")
-
-		contracts := deployment.ContractNames(a)
-		// d := c.FlowJSON.GetAccountDeployment(c.network.Name, a)
-		for _, ct := range contracts {
-			// get acct and deploy contract
-			acct := c.FlowJSON.Account(a)
-			contract := c.GetContractCdc(ct)
-			txRes, err := c.NewTxFromString(
-				tmp.TX_CONTRACT_DEPLOY,
-				acct,
-				contract.NameAsCadenceString(),
-				cadence.String(hex.EncodeToString(contract.CdcBytes())),
-			).SignAndSend()
-			if err != nil {
-				panic(err)
-			}
-			if txRes.Error != nil {
-				panic(txRes.Error)
+		address, err := c.createAccount(s.PublicKey(), s.HashAlgorithm())
+		if err != nil {
+			return &AccountError{Account: n, Err: err}
+		}
+		if address != a.CadenceAddress() {
+			return &AccountError{
+				Account: n,
+				Err:     fmt.Errorf("expected address %s but the emulator created %s", a.Address, address),
 			}
-			c.Logger.Info(fmt.Sprintf("Contract=%s Deployed", ct))
 		}
+
+		c.Logger.Info(fmt.Sprintf("Account=%s Created", address))
 	}
+
+	return nil
 }
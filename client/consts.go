@@ -3,3 +3,9 @@ package client
 const (
 	DEFAULT_KEYS_SEED_PHRASE = "elephant ears space cowboy octopus rodeo potato cannon pineapple"
 )
+
+// EMULATOR_TX_EXPIRY is how many blocks a transaction built against the
+// embedded emulator stays valid for, as on Flow's networks. The emulator's
+// own default expires transactions once another block is committed, which
+// rejects transactions built concurrently.
+const EMULATOR_TX_EXPIRY = 600
@@ -1,28 +1,134 @@
 package client
 
 import (
+	"encoding/hex"
 	"fmt"
+	"strings"
 
-	"github.com/rrossilli/glow/util"
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
 
 	"github.com/rrossilli/glow/model"
+	"github.com/rrossilli/glow/tmp"
+	"github.com/rrossilli/glow/util"
 )
 
-// Get Contract by name
+// MustGetContractCdc gets a contract by name like GetContractCdcE, but
+// panics if the contract cannot be loaded.
+func (c *GlowClient) MustGetContractCdc(name string) model.ContractCdc {
+	contract, err := c.GetContractCdcE(name)
+	if err != nil {
+		panic(err)
+	}
+	return contract
+}
+
+// Get Contract by name.
+//
+// Deprecated: GetContractCdc panics if the contract cannot be loaded; use
+// GetContractCdcE, or MustGetContractCdc.
 func (c *GlowClient) GetContractCdc(name string) model.ContractCdc {
+	return c.MustGetContractCdc(name)
+}
+
+// GetContractCdcE gets a contract by name along with its import-resolved Cadence.
+func (c *GlowClient) GetContractCdcE(name string) (model.ContractCdc, error) {
 	contract := c.FlowJSON.Contract(name)
 	if util.IsEmpty(contract) {
-		panic(fmt.Sprintf("contract not found in flow.json: %s", name))
+		return model.ContractCdc{}, &ContractError{Contract: name, Err: ErrContractNotFound}
 	}
 
 	cdc, err := c.CadenceFromFile(contract.Source)
 	if err != nil {
-		panic(err)
+		return model.ContractCdc{}, &ContractError{Contract: name, Err: err}
 	}
 
 	return model.ContractCdc{
 		Contract: contract,
 		Name:     name,
 		Cdc:      cdc,
+	}, nil
+}
+
+// DeployContract deploys the named flow.json contract to acct, passing args
+// to the contract's initializer.
+func (c *GlowClient) DeployContract(
+	acct model.Account,
+	name string,
+	args ...cadence.Value,
+) (*TxResult, error) {
+	contract, err := c.GetContractCdcE(name)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := contractDeployTx(contract.Cdc, args)
+	if err != nil {
+		return nil, &ContractError{Contract: name, Err: err}
+	}
+
+	txArgs := append([]cadence.Value{
+		contract.NameAsCadenceString(),
+		cadence.String(hex.EncodeToString(contract.CdcBytes())),
+	}, args...)
+
+	res, err := c.NewTxFromString(template, acct, txArgs...).SignAndSend()
+	if err != nil {
+		return nil, &ContractError{Contract: name, Err: err}
+	}
+	return res, nil
+}
+
+// contractDeployTx returns the contract deploy transaction, extended with a
+// parameter per initializer argument, typed as the contract's init declares.
+// The contract's imports are repeated so that imported types resolve.
+func contractDeployTx(cdc string, args []cadence.Value) (string, error) {
+	if len(args) == 0 {
+		return tmp.TX_CONTRACT_DEPLOY, nil
+	}
+
+	program, err := model.ParseCadence([]byte(cdc))
+	if err != nil {
+		return "", fmt.Errorf("parse contract: %w", err)
+	}
+	initParams := contractInitParams(program)
+	if len(initParams) != len(args) {
+		return "", fmt.Errorf("init takes %d arguments, got %d", len(initParams), len(args))
+	}
+
+	var imports []string
+	for _, imp := range program.ImportDeclarations() {
+		imports = append(imports, cdc[imp.StartPos.Offset:imp.EndPos.Offset+1])
+	}
+
+	params := []string{"name: String", "code: String"}
+	var names []string
+	for i, p := range initParams {
+		params = append(params, fmt.Sprintf("arg%d: %s", i, p.TypeAnnotation))
+		names = append(names, fmt.Sprintf("arg%d", i))
+	}
+
+	return fmt.Sprintf(`
+	%s
+	transaction(%s) {
+		prepare(signer: AuthAccount) {
+			signer.contracts.add(name: name, code: code.decodeHex(), %s)
+		}
+	}`, strings.Join(imports, "\n\t"), strings.Join(params, ", "), strings.Join(names, ", ")), nil
+}
+
+// contractInitParams returns the parameters of the contract's initializer.
+func contractInitParams(program *ast.Program) []*ast.Parameter {
+	for _, decl := range program.CompositeDeclarations() {
+		if decl.Kind() != common.CompositeKindContract {
+			continue
+		}
+		inits := decl.Members.Initializers()
+		if len(inits) == 0 || inits[0].FunctionDeclaration.ParameterList == nil {
+			return nil
+		}
+		return inits[0].FunctionDeclaration.ParameterList.Parameters
 	}
+	return nil
 }
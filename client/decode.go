@@ -0,0 +1,339 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// RESOURCE_UUID is the field every resource has, which Go structs need not
+// declare in strict mode.
+const RESOURCE_UUID = "uuid"
+
+var (
+	bigIntType      = reflect.TypeOf(big.Int{})
+	flowAddressType = reflect.TypeOf(flow.Address{})
+)
+
+// ExecInto executes the script and decodes its result into out, which must
+// be a non-nil pointer; see Decode.
+func (sc *Sc) ExecInto(out interface{}) error {
+	v, err := sc.Exec()
+	if err != nil {
+		return err
+	}
+	return Decode(v, out)
+}
+
+// ExecIntoStrict is like ExecInto, decoding as DecodeStrict does.
+func (sc *Sc) ExecIntoStrict(out interface{}) error {
+	v, err := sc.Exec()
+	if err != nil {
+		return err
+	}
+	return DecodeStrict(v, out)
+}
+
+// Decode decodes a Cadence value into out, which must be a non-nil pointer:
+//
+//   - structs, resources, events and contracts into Go structs, fields
+//     matched by CADENCE_TAG or by name, ignoring case; a "-" tag skips a field
+//   - optionals into pointers, nil if empty, or into the inner type
+//   - arrays into slices and arrays, dictionaries into maps
+//   - UFix64 and Fix64 into floats or decimal strings
+//   - addresses into flow.Address or hex strings
+//   - integers into Go integers, range checked, floats and big.Int
+//   - strings, characters and paths into strings, Bool into bool
+//
+// Any value decodes into a cadence.Value or a Cadence type of its own, and
+// into an interface{} as returned by ToGoValue. Fields present on only one
+// side are ignored; use DecodeStrict to reject them. Errors are returned as
+// a *DecodeError naming the path to the value that failed.
+func Decode(v cadence.Value, out interface{}) error {
+	return decodeInto(v, out, false)
+}
+
+// DecodeStrict is like Decode, but fails on Cadence fields with no Go
+// field and Go fields with no Cadence field, except the uuid of resources.
+func DecodeStrict(v cadence.Value, out interface{}) error {
+	return decodeInto(v, out, true)
+}
+
+func decodeInto(v cadence.Value, out interface{}, strict bool) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return &DecodeError{Err: fmt.Errorf("decode target must be a non-nil pointer, got %T", out)}
+	}
+	d := decoder{strict: strict}
+	return d.decode(v, rv.Elem(), "")
+}
+
+type decoder struct {
+	strict   bool // reject fields present on only one side
+	required bool // reject Go fields with no Cadence field
+}
+
+// decode sets rv to the decoded v. path locates v in the decoded value.
+func (d decoder) decode(v cadence.Value, rv reflect.Value, path string) error {
+	fail := func(format string, args ...interface{}) error {
+		return &DecodeError{Path: path, Err: fmt.Errorf(format, args...)}
+	}
+	mismatch := func() error {
+		return fail("cannot decode %s into %s", cadenceTypeName(v), rv.Type())
+	}
+
+	// the value's own type, cadence.Value and interface{}
+	emptyInterface := rv.Kind() == reflect.Interface && rv.NumMethod() == 0
+	if v != nil && !emptyInterface && reflect.TypeOf(v).AssignableTo(rv.Type()) {
+		rv.Set(reflect.ValueOf(v))
+		return nil
+	}
+	if emptyInterface {
+		var goValue interface{}
+		if v != nil {
+			goValue = v.ToGoValue()
+		}
+		if goValue == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+		} else {
+			rv.Set(reflect.ValueOf(goValue))
+		}
+		return nil
+	}
+
+	if opt, ok := v.(cadence.Optional); ok {
+		if opt.Value == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		return d.decode(opt.Value, rv, path)
+	}
+	if rv.Kind() == reflect.Pointer {
+		if v == nil {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return d.decode(v, rv.Elem(), path)
+	}
+	if v == nil {
+		return fail("cannot decode nil into %s", rv.Type())
+	}
+
+	switch x := v.(type) {
+	case cadence.Array:
+		switch rv.Kind() {
+		case reflect.Slice:
+			rv.Set(reflect.MakeSlice(rv.Type(), len(x.Values), len(x.Values)))
+		case reflect.Array:
+			if rv.Len() != len(x.Values) {
+				return fail("cannot decode %d elements into %s", len(x.Values), rv.Type())
+			}
+		default:
+			return mismatch()
+		}
+		for i, e := range x.Values {
+			if err := d.decode(e, rv.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case cadence.Dictionary:
+		if rv.Kind() != reflect.Map {
+			return mismatch()
+		}
+		m := reflect.MakeMapWithSize(rv.Type(), len(x.Pairs))
+		for _, p := range x.Pairs {
+			key := reflect.New(rv.Type().Key()).Elem()
+			keyPath := fmt.Sprintf("%s[%s]", path, p.Key)
+			if err := d.decode(p.Key, key, keyPath); err != nil {
+				return err
+			}
+			value := reflect.New(rv.Type().Elem()).Elem()
+			if err := d.decode(p.Value, value, keyPath); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, value)
+		}
+		rv.Set(m)
+		return nil
+
+	case cadence.HasFields:
+		if rv.Kind() != reflect.Struct {
+			return mismatch()
+		}
+		return d.decodeFields(x, rv, path)
+
+	case cadence.Address:
+		switch {
+		case rv.Type() == flowAddressType:
+			rv.Set(reflect.ValueOf(flow.Address(x)))
+		case rv.Kind() == reflect.String:
+			rv.SetString(x.String())
+		default:
+			return mismatch()
+		}
+		return nil
+
+	case cadence.UFix64, cadence.Fix64:
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f, _, err := big.ParseFloat(x.String(), 10, 64, big.ToNearestEven)
+			if err != nil {
+				return fail("%v", err)
+			}
+			f64, _ := f.Float64()
+			rv.SetFloat(f64)
+		case reflect.String:
+			rv.SetString(x.String())
+		default:
+			return mismatch()
+		}
+		return nil
+
+	case cadence.String:
+		if rv.Kind() != reflect.String {
+			return mismatch()
+		}
+		rv.SetString(string(x))
+		return nil
+
+	case cadence.Character:
+		if rv.Kind() != reflect.String {
+			return mismatch()
+		}
+		rv.SetString(string(x))
+		return nil
+
+	case cadence.Path:
+		if rv.Kind() != reflect.String {
+			return mismatch()
+		}
+		rv.SetString(x.String())
+		return nil
+
+	case cadence.Bool:
+		if rv.Kind() != reflect.Bool {
+			return mismatch()
+		}
+		rv.SetBool(bool(x))
+		return nil
+	}
+
+	if n, ok := cadenceInteger(v); ok {
+		switch {
+		case rv.Type() == bigIntType:
+			rv.Set(reflect.ValueOf(n).Elem())
+		case rv.CanInt():
+			if !n.IsInt64() || rv.OverflowInt(n.Int64()) {
+				return fail("%s overflows %s", n, rv.Type())
+			}
+			rv.SetInt(n.Int64())
+		case rv.CanUint():
+			if !n.IsUint64() || rv.OverflowUint(n.Uint64()) {
+				return fail("%s overflows %s", n, rv.Type())
+			}
+			rv.SetUint(n.Uint64())
+		case rv.CanFloat():
+			f, _ := new(big.Float).SetInt(n).Float64()
+			rv.SetFloat(f)
+		default:
+			return mismatch()
+		}
+		return nil
+	}
+
+	return mismatch()
+}
+
+// decodeFields decodes the fields of a composite value into a Go struct.
+func (d decoder) decodeFields(v cadence.HasFields, rv reflect.Value, path string) error {
+	_, isResource := v.(cadence.Resource)
+
+	set := map[int]bool{}
+	values := v.GetFieldValues()
+	for i, f := range v.GetFields() {
+		fieldPath := path + "." + f.Identifier
+		index, ok := goField(rv.Type(), f.Identifier)
+		if !ok {
+			if d.strict && !(isResource && f.Identifier == RESOURCE_UUID) {
+				return &DecodeError{Path: fieldPath, Err: fmt.Errorf("no field in %s", rv.Type())}
+			}
+			continue
+		}
+		if err := d.decode(values[i], rv.Field(index), fieldPath); err != nil {
+			return err
+		}
+		set[index] = true
+	}
+
+	if d.strict || d.required {
+		for i := 0; i < rv.NumField(); i++ {
+			f := rv.Type().Field(i)
+			if f.IsExported() && f.Tag.Get(CADENCE_TAG) != "-" && !set[i] {
+				return &DecodeError{Path: path, Err: fmt.Errorf("no Cadence field for %s.%s", rv.Type(), f.Name)}
+			}
+		}
+	}
+	return nil
+}
+
+// goField returns the index of the Go struct field for the Cadence field name.
+func goField(t reflect.Type, name string) (int, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() && f.Tag.Get(CADENCE_TAG) == name {
+			return i, true
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() && f.Tag.Get(CADENCE_TAG) == "" && strings.EqualFold(f.Name, name) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// cadenceInteger returns the value of a Cadence integer.
+func cadenceInteger(v cadence.Value) (*big.Int, bool) {
+	switch n := v.(type) {
+	case interface{ Big() *big.Int }:
+		return n.Big(), true
+	case cadence.Int8:
+		return big.NewInt(int64(n)), true
+	case cadence.Int16:
+		return big.NewInt(int64(n)), true
+	case cadence.Int32:
+		return big.NewInt(int64(n)), true
+	case cadence.Int64:
+		return big.NewInt(int64(n)), true
+	case cadence.UInt8:
+		return new(big.Int).SetUint64(uint64(n)), true
+	case cadence.UInt16:
+		return new(big.Int).SetUint64(uint64(n)), true
+	case cadence.UInt32:
+		return new(big.Int).SetUint64(uint64(n)), true
+	case cadence.UInt64:
+		return new(big.Int).SetUint64(uint64(n)), true
+	case cadence.Word8:
+		return new(big.Int).SetUint64(uint64(n)), true
+	case cadence.Word16:
+		return new(big.Int).SetUint64(uint64(n)), true
+	case cadence.Word32:
+		return new(big.Int).SetUint64(uint64(n)), true
+	case cadence.Word64:
+		return new(big.Int).SetUint64(uint64(n)), true
+	}
+	return nil, false
+}
+
+// cadenceTypeName names the kind of Cadence value v for errors.
+func cadenceTypeName(v cadence.Value) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", v), "cadence.")
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/rrossilli/glow/model"
+	"github.com/rrossilli/glow/tmp"
+)
+
+// Contract deployment statuses reported by Deploy.
+const (
+	DEPLOY_ADDED     = "added"
+	DEPLOY_UPDATED   = "updated"
+	DEPLOY_UNCHANGED = "unchanged"
+	DEPLOY_FAILED    = "failed"
+)
+
+// ContractDeploymentResult is the outcome of deploying one contract.
+type ContractDeploymentResult struct {
+	Account  string
+	Address  string
+	Contract string
+	Status   string
+	Err      error
+}
+
+// DeploymentReport lists the outcome of every contract in a deployment.
+type DeploymentReport []ContractDeploymentResult
+
+// Failed returns the results with status DEPLOY_FAILED.
+func (r DeploymentReport) Failed() []ContractDeploymentResult {
+	var failed []ContractDeploymentResult
+	for _, res := range r {
+		if res.Status == DEPLOY_FAILED {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Status returns the status of the named contract, or "" if it was not deployed.
+func (r DeploymentReport) Status(contract string) string {
+	for _, res := range r {
+		if res.Contract == contract {
+			return res.Status
+		}
+	}
+	return ""
+}
+
+// Deploy deploys the network's contracts from flow.json. Contracts are
+// deployed after the contracts they import, across all accounts. Contracts
+// already on chain are skipped if their code is unchanged and updated
+// otherwise, so Deploy can be re-run against a long-lived network. Every
+// contract is attempted; the returned error summarizes any failures.
+func (c *GlowClient) Deploy() (DeploymentReport, error) {
+	c.Logger.Info("Deploy Contracts:")
+
+	order, err := c.FlowJSON.DeploymentOrder(c.network.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	onChain := map[flow.Address]map[string][]byte{}
+	var report DeploymentReport
+	for _, d := range order {
+		acct := c.FlowJSON.Account(d.Account)
+		res := ContractDeploymentResult{
+			Account:  d.Account,
+			Address:  acct.Address,
+			Contract: d.Contract.Name,
+		}
+
+		// fetch each account's deployed contracts once
+		existing, ok := onChain[acct.FlowAddress()]
+		if !ok {
+			a, err := c.GetAccount(acct.Address)
+			if err != nil {
+				res.Status, res.Err = DEPLOY_FAILED, &AccountError{Account: d.Account, Err: err}
+				report = append(report, res)
+				continue
+			}
+			existing = a.Contracts
+			onChain[acct.FlowAddress()] = existing
+		}
+
+		res.Status, res.Err = c.deployContract(d.Account, d.Contract, existing)
+		if res.Err != nil {
+			res.Err = &AccountError{Account: d.Account, Err: res.Err}
+		}
+		c.Logger.Info(fmt.Sprintf("Contract=%s Account=%s Status=%s", res.Contract, res.Account, res.Status))
+		report = append(report, res)
+	}
+
+	if failed := report.Failed(); len(failed) > 0 {
+		var msgs []string
+		for _, f := range failed {
+			msgs = append(msgs, f.Err.Error())
+		}
+		return report, fmt.Errorf("%d contract(s) failed to deploy:\n%s", len(failed), strings.Join(msgs, "\n"))
+	}
+
+	return report, nil
+}
+
+// deployContract adds, updates or skips a contract depending on the code
+// already deployed to the account. Init args are only used when adding.
+// Errors name the contract.
+func (c *GlowClient) deployContract(acctName string, dc model.DeploymentContract, existing map[string][]byte) (string, error) {
+	contract, err := c.GetContractCdcE(dc.Name)
+	if err != nil {
+		return DEPLOY_FAILED, err
+	}
+
+	code, ok := existing[dc.Name]
+	if !ok {
+		args, err := dc.CadenceArgs()
+		if err != nil {
+			return DEPLOY_FAILED, &ContractError{Contract: dc.Name, Err: err}
+		}
+		_, err = c.DeployContract(c.FlowJSON.Account(acctName), dc.Name, args...)
+		if err != nil {
+			return DEPLOY_FAILED, err
+		}
+		return DEPLOY_ADDED, nil
+	}
+
+	if sha256.Sum256(code) == sha256.Sum256(contract.CdcBytes()) {
+		return DEPLOY_UNCHANGED, nil
+	}
+
+	_, err = c.NewTxFromString(
+		tmp.TX_CONTRACT_UPDATE,
+		c.FlowJSON.Account(acctName),
+		contract.NameAsCadenceString(),
+		cadence.String(hex.EncodeToString(contract.CdcBytes())),
+	).SignAndSend()
+	if err != nil {
+		return DEPLOY_FAILED, &ContractError{Contract: dc.Name, Err: err}
+	}
+
+	return DEPLOY_UPDATED, nil
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/rrossilli/glow/model"
+)
+
+// Stages of client startup reported by StartError.
+const (
+	STAGE_LOAD_CONFIG      = "load config"
+	STAGE_PARSE_FLOW_JSON  = "parse flow.json"
+	STAGE_VALIDATE_CONFIG  = "validate config"
+	STAGE_RESOLVE_NETWORK  = "resolve network"
+	STAGE_CREATE_GATEWAY   = "create gateway"
+	STAGE_CREATE_ACCOUNTS  = "create accounts"
+	STAGE_DEPLOY_CONTRACTS = "deploy contracts"
+)
+
+var (
+	// ErrContractNotFound is returned when a contract is not defined in flow.json.
+	ErrContractNotFound = errors.New("contract not found in flow.json")
+)
+
+// StartError is returned by StartE when a startup stage fails.
+type StartError struct {
+	Stage string
+	Err   error
+}
+
+func (e *StartError) Error() string {
+	return fmt.Sprintf("glow client start failed at stage %q: %v", e.Stage, e.Err)
+}
+
+func (e *StartError) Unwrap() error {
+	return e.Err
+}
+
+// AccountError names the flow.json account an operation failed for.
+type AccountError struct {
+	Account string
+	Err     error
+}
+
+func (e *AccountError) Error() string {
+	return fmt.Sprintf("account %s: %v", e.Account, e.Err)
+}
+
+func (e *AccountError) Unwrap() error {
+	return e.Err
+}
+
+// ContractError names the contract an operation failed for.
+type ContractError struct {
+	Contract string
+	Err      error
+}
+
+func (e *ContractError) Error() string {
+	return fmt.Sprintf("contract %s: %v", e.Contract, e.Err)
+}
+
+func (e *ContractError) Unwrap() error {
+	return e.Err
+}
+
+// ArgError names the argument ArgsGo could not convert to the Cadence type
+// of its parameter.
+type ArgError struct {
+	Index int    // position of the argument, from 0
+	Param string // parameter name
+	Type  string // declared Cadence type
+	Err   error
+}
+
+func (e *ArgError) Error() string {
+	return fmt.Sprintf("argument %d (%s: %s): %v", e.Index, e.Param, e.Type, e.Err)
+}
+
+func (e *ArgError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError names the part of a Cadence value that could not be decoded,
+// as a path such as .royalties[0].cut; the path is empty for the value itself.
+type DecodeError struct {
+	Path string
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("decode: %v", e.Err)
+	}
+	return fmt.Sprintf("decode %s: %v", e.Path, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// CadenceFileError names the Cadence file that could not be loaded.
+type CadenceFileError struct {
+	Path string
+	Err  error
+}
+
+func (e *CadenceFileError) Error() string {
+	return fmt.Sprintf("cadence file %s: %v", e.Path, e.Err)
+}
+
+func (e *CadenceFileError) Unwrap() error {
+	return e.Err
+}
+
+// UnresolvedImportsError lists the imports of Cadence code naming contracts
+// that are neither aliased nor deployed on the network in flow.json. It is
+// returned before the code is sent.
+type UnresolvedImportsError struct {
+	Network string
+	Imports []model.Import
+}
+
+func (e *UnresolvedImportsError) Error() string {
+	lines := make([]string, len(e.Imports))
+	for i, imp := range e.Imports {
+		lines[i] = fmt.Sprintf("  line %d: %s", imp.Line, imp)
+	}
+	return fmt.Sprintf("%d import(s) not resolved on %s by flow.json:\n%s", len(lines), e.Network, strings.Join(lines, "\n"))
+}
+
+// MissingSignaturesError is returned when sending a transaction that lacks
+// signatures its accounts require.
+type MissingSignaturesError struct {
+	ID      flow.Identifier
+	Missing []string // description of each missing signature
+}
+
+func (e *MissingSignaturesError) Error() string {
+	return fmt.Sprintf("transaction %s is missing signatures: %s", e.ID, strings.Join(e.Missing, "; "))
+}
+
+// ValidationError lists the flow.json problems found during startup.
+type ValidationError struct {
+	Diagnostics []model.Diagnostic
+}
+
+func (e *ValidationError) Error() string {
+	var lines []string
+	for _, d := range e.Diagnostics {
+		if d.Severity == model.SEVERITY_ERROR {
+			lines = append(lines, "  "+d.String())
+		}
+	}
+	return fmt.Sprintf("flow.json has %d error(s):\n%s", len(lines), strings.Join(lines, "\n"))
+}
@@ -2,12 +2,31 @@ package client
 
 import (
 	"crypto/rand"
+	"errors"
+	"fmt"
 
 	"github.com/onflow/flow-go-sdk/crypto"
 
+	"github.com/rrossilli/glow/model"
+	"github.com/rrossilli/glow/signer"
 	"github.com/rrossilli/glow/util"
 )
 
+// Raw values of Cadence's SignatureAlgorithm enum.
+var cadenceSigAlgos = map[crypto.SignatureAlgorithm]uint8{
+	crypto.ECDSA_P256:      1,
+	crypto.ECDSA_secp256k1: 2,
+}
+
+// Raw values of Cadence's HashAlgorithm enum.
+var cadenceHashAlgos = map[crypto.HashAlgorithm]uint8{
+	crypto.SHA2_256:  1,
+	crypto.SHA2_384:  2,
+	crypto.SHA3_256:  3,
+	crypto.SHA3_384:  4,
+	crypto.Keccak256: 6,
+}
+
 // Create new "crypto" private key from seed phrase.
 func (c *GlowClient) NewPrivateKey(seedPhrase string) (crypto.PrivateKey, error) {
 	seed := []byte(seedPhrase)
@@ -43,3 +62,55 @@ func (c *GlowClient) NewPublicKeyFromHex(privKey string) (crypto.PublicKey, erro
 
 	return key, nil
 }
+
+// keyAlgorithms returns the signature and hash algorithms of a key being
+// added to an account, defaulting to model.DEFAULT_SIG_ALGO and
+// model.DEFAULT_HASH_ALGO for those it does not set.
+func keyAlgorithms(k model.AccountKey) (crypto.SignatureAlgorithm, crypto.HashAlgorithm, error) {
+	sigAlgo, hashAlgo := k.SigAlgorithm(), k.HashAlgorithm()
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return sigAlgo, hashAlgo, fmt.Errorf("unknown signature algorithm %q", k.SigAlgo)
+	}
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return sigAlgo, hashAlgo, fmt.Errorf("unknown hash algorithm %q", k.HashAlgo)
+	}
+	return sigAlgo, hashAlgo, nil
+}
+
+// Close releases the connections held by the signers of account keys, such
+// as those of KMS keys. The client remains usable: signers are created again
+// when next needed.
+func (c *GlowClient) Close() error {
+	c.signersMu.Lock()
+	defer c.signersMu.Unlock()
+
+	var errs []error
+	for id, s := range c.signers {
+		errs = append(errs, signer.Close(s))
+		delete(c.signers, id)
+	}
+	return errors.Join(errs...)
+}
+
+// keySigner returns the signer of an account key. Signers created from a
+// key file, keystore or KMS are reused, as creating them reads the file,
+// decrypts the keystore or calls the KMS.
+func (c *GlowClient) keySigner(a model.Account, k model.AccountKey) (signer.Signer, error) {
+	if k.Signer != nil || k.Type == "" || k.Type == model.KEY_TYPE_HEX {
+		return k.ResolveSigner()
+	}
+
+	id := fmt.Sprintf("%s/%d/%s/%s/%s", a.FlowAddress(), k.Index, k.Type, k.Location, k.ResourceID)
+	c.signersMu.Lock()
+	defer c.signersMu.Unlock()
+	if s, ok := c.signers[id]; ok {
+		return s, nil
+	}
+
+	s, err := k.ResolveSigner()
+	if err != nil {
+		return nil, err
+	}
+	c.signers[id] = s
+	return s, nil
+}
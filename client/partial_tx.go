@@ -0,0 +1,372 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/rrossilli/glow/model"
+	"github.com/rrossilli/glow/util"
+)
+
+// Formats partial transactions are exported in.
+const (
+	TX_FORMAT_RLP  = "rlp"  // hex-encoded RLP, as written by the Flow CLI
+	TX_FORMAT_JSON = "json" // readable JSON with the script as text
+)
+
+// ErrPayloadAfterEnvelope is returned when adding a payload signature to a
+// transaction the payer has already signed; the payer signs last, as the
+// envelope signature covers the payload signatures.
+var ErrPayloadAfterEnvelope = errors.New("payload signatures must be added before the payer signs the envelope")
+
+// txJSON is the JSON format of a partial transaction. Arguments are kept as
+// their exact JSON-Cadence text so the payload, and its signatures, survive
+// the round trip.
+type txJSON struct {
+	Script             string          `json:"script"`
+	Arguments          []string        `json:"arguments"`
+	ReferenceBlockID   string          `json:"referenceBlockId"`
+	GasLimit           uint64          `json:"gasLimit"`
+	ProposalKey        proposalKeyJSON `json:"proposalKey"`
+	Payer              string          `json:"payer"`
+	Authorizers        []string        `json:"authorizers"`
+	PayloadSignatures  []signatureJSON `json:"payloadSignatures"`
+	EnvelopeSignatures []signatureJSON `json:"envelopeSignatures"`
+}
+
+type proposalKeyJSON struct {
+	Address        string `json:"address"`
+	KeyIndex       int    `json:"keyIndex"`
+	SequenceNumber uint64 `json:"sequenceNumber"`
+}
+
+type signatureJSON struct {
+	Address   string `json:"address"`
+	KeyIndex  int    `json:"keyIndex"`
+	Signature string `json:"signature"`
+}
+
+// SignWith signs the transaction with the account's signing keys, and its
+// proposal key if the account proposes. The payer signs the envelope and
+// other accounts the payload. Keys that already signed are skipped.
+func (s *SignedTx) SignWith(a model.Account) error {
+	tx := s.FlowTransaction()
+	address := a.FlowAddress()
+	envelope := address == tx.Payer
+
+	if !envelope && !isPayloadSigner(tx, address) {
+		return &AccountError{Account: a.Address, Err: fmt.Errorf("not a signer of transaction %s", tx.ID())}
+	}
+	if !envelope && len(tx.EnvelopeSignatures) > 0 {
+		return &AccountError{Account: a.Address, Err: ErrPayloadAfterEnvelope}
+	}
+
+	indexes := a.SigningKeyIndexes()
+	if address == tx.ProposalKey.Address {
+		indexes = append([]int{tx.ProposalKey.KeyIndex}, indexes...)
+	}
+	for _, index := range indexes {
+		sigs := tx.PayloadSignatures
+		if envelope {
+			sigs = tx.EnvelopeSignatures
+		}
+		if hasSignature(sigs, address, index) {
+			continue
+		}
+
+		if err := s.client.signWithKey(tx, a, signingKey{address: address, index: index}, envelope); err != nil {
+			return &AccountError{Account: a.Address, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// AddSignature adds the signatures of the same transaction signed
+// elsewhere, e.g. by a partner who imported an export of it, signed it with
+// their keys and exported it again. data is the export, in either format.
+func (s *SignedTx) AddSignature(data []byte) error {
+	other, err := decodeTx(data)
+	if err != nil {
+		return err
+	}
+
+	tx := s.FlowTransaction()
+	if !bytes.Equal(other.PayloadMessage(), tx.PayloadMessage()) {
+		return fmt.Errorf("signatures are for a different transaction")
+	}
+
+	for _, sig := range other.PayloadSignatures {
+		if hasSignature(tx.PayloadSignatures, sig.Address, sig.KeyIndex) {
+			continue
+		}
+		if len(tx.EnvelopeSignatures) > 0 {
+			return ErrPayloadAfterEnvelope
+		}
+		tx.AddPayloadSignature(sig.Address, sig.KeyIndex, sig.Signature)
+	}
+
+	if len(other.EnvelopeSignatures) == 0 {
+		return nil
+	}
+	if !bytes.Equal(other.EnvelopeMessage(), tx.EnvelopeMessage()) {
+		return fmt.Errorf("envelope signatures were made over different payload signatures")
+	}
+	for _, sig := range other.EnvelopeSignatures {
+		if !hasSignature(tx.EnvelopeSignatures, sig.Address, sig.KeyIndex) {
+			tx.AddEnvelopeSignature(sig.Address, sig.KeyIndex, sig.Signature)
+		}
+	}
+
+	return nil
+}
+
+// AddSignatureFile adds the signatures of a transaction exported to a file
+// elsewhere. See AddSignature.
+func (s *SignedTx) AddSignatureFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := s.AddSignature(data); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// MissingSignatures describes each signature the transaction still needs
+// before it can be sent: payload signatures of the proposer and authorizers
+// and envelope signatures of the payer, each reaching the account's key
+// weight threshold, and a signature by the proposal key. Account keys are
+// looked up on chain.
+func (s *SignedTx) MissingSignatures() ([]string, error) {
+	tx := s.FlowTransaction()
+
+	var missing []string
+	for _, address := range signerAddresses(tx) {
+		kind, sigs := "payload", tx.PayloadSignatures
+		if address == tx.Payer {
+			kind, sigs = "envelope", tx.EnvelopeSignatures
+		}
+
+		acct, err := s.client.FlowKit.GetAccount(s.ctx, address)
+		if err != nil {
+			return nil, &AccountError{Account: util.PrependHexPrefix(address.Hex()), Err: err}
+		}
+
+		weight := 0
+		signed := map[int]bool{}
+		for _, sig := range sigs {
+			if sig.Address != address || signed[sig.KeyIndex] {
+				continue
+			}
+			signed[sig.KeyIndex] = true
+			if sig.KeyIndex < len(acct.Keys) && !acct.Keys[sig.KeyIndex].Revoked {
+				weight += acct.Keys[sig.KeyIndex].Weight
+			}
+		}
+
+		if weight < flow.AccountKeyWeightThreshold {
+			missing = append(missing, fmt.Sprintf(
+				"%s signatures of %s (weight %d of %d)",
+				kind, util.PrependHexPrefix(address.Hex()), weight, flow.AccountKeyWeightThreshold,
+			))
+		}
+		if address == tx.ProposalKey.Address && !signed[tx.ProposalKey.KeyIndex] {
+			missing = append(missing, fmt.Sprintf(
+				"%s signature of proposal key %d of %s",
+				kind, tx.ProposalKey.KeyIndex, util.PrependHexPrefix(address.Hex()),
+			))
+		}
+	}
+
+	return missing, nil
+}
+
+// Export encodes the transaction and its signatures so far in TX_FORMAT_RLP
+// or TX_FORMAT_JSON, for other parties to import and sign.
+func (s *SignedTx) Export(format string) ([]byte, error) {
+	tx := s.FlowTransaction()
+
+	switch format {
+	case TX_FORMAT_RLP:
+		return []byte(hex.EncodeToString(tx.Encode())), nil
+	case TX_FORMAT_JSON:
+		j := txJSON{
+			Script:           string(tx.Script),
+			ReferenceBlockID: tx.ReferenceBlockID.Hex(),
+			GasLimit:         tx.GasLimit,
+			ProposalKey: proposalKeyJSON{
+				Address:        tx.ProposalKey.Address.Hex(),
+				KeyIndex:       tx.ProposalKey.KeyIndex,
+				SequenceNumber: tx.ProposalKey.SequenceNumber,
+			},
+			Payer:              tx.Payer.Hex(),
+			Arguments:          []string{},
+			Authorizers:        []string{},
+			PayloadSignatures:  signaturesJSON(tx.PayloadSignatures),
+			EnvelopeSignatures: signaturesJSON(tx.EnvelopeSignatures),
+		}
+		for _, arg := range tx.Arguments {
+			j.Arguments = append(j.Arguments, string(arg))
+		}
+		for _, a := range tx.Authorizers {
+			j.Authorizers = append(j.Authorizers, a.Hex())
+		}
+		return json.MarshalIndent(j, "", "  ")
+	}
+	return nil, fmt.Errorf("unknown transaction format %q", format)
+}
+
+// ExportFile writes the transaction to a file. See Export.
+func (s *SignedTx) ExportFile(path, format string) error {
+	data, err := s.Export(format)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ImportTx decodes a transaction exported in either format, or as binary
+// RLP, to sign, add signatures to or send.
+func (c *GlowClient) ImportTx(data []byte) (*SignedTx, error) {
+	tx, err := decodeTx(data)
+	if err != nil {
+		return nil, err
+	}
+
+	flowTx, err := transactions.NewFromPayload([]byte(hex.EncodeToString(tx.Encode())))
+	if err != nil {
+		return nil, err
+	}
+	return &SignedTx{
+		ctx:    context.Background(),
+		flowTx: flowTx,
+		client: c,
+	}, nil
+}
+
+// ImportTxFile reads a transaction exported to a file. See ImportTx.
+func (c *GlowClient) ImportTxFile(path string) (*SignedTx, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.ImportTx(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return tx, nil
+}
+
+// decodeTx decodes a transaction from JSON, hex-encoded RLP or binary RLP.
+func decodeTx(data []byte) (*flow.Transaction, error) {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return decodeTxJSON(trimmed)
+	}
+	if b, err := hex.DecodeString(string(trimmed)); err == nil {
+		data = b
+	}
+
+	tx, err := flow.DecodeTransaction(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode transaction: %w", err)
+	}
+	return tx, nil
+}
+
+func decodeTxJSON(data []byte) (*flow.Transaction, error) {
+	var j txJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("decode transaction: %w", err)
+	}
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(j.Script)).
+		SetReferenceBlockID(flow.HexToID(j.ReferenceBlockID)).
+		SetGasLimit(j.GasLimit).
+		SetProposalKey(flow.HexToAddress(j.ProposalKey.Address), j.ProposalKey.KeyIndex, j.ProposalKey.SequenceNumber).
+		SetPayer(flow.HexToAddress(j.Payer))
+	for _, arg := range j.Arguments {
+		tx.AddRawArgument([]byte(arg))
+	}
+	for _, a := range j.Authorizers {
+		tx.AddAuthorizer(flow.HexToAddress(a))
+	}
+
+	for _, envelope := range []bool{false, true} {
+		sigs := j.PayloadSignatures
+		if envelope {
+			sigs = j.EnvelopeSignatures
+		}
+		for _, sig := range sigs {
+			b, err := hex.DecodeString(sig.Signature)
+			if err != nil {
+				return nil, fmt.Errorf("decode transaction: signature of %s: %w", sig.Address, err)
+			}
+			if envelope {
+				tx.AddEnvelopeSignature(flow.HexToAddress(sig.Address), sig.KeyIndex, b)
+			} else {
+				tx.AddPayloadSignature(flow.HexToAddress(sig.Address), sig.KeyIndex, b)
+			}
+		}
+	}
+
+	return tx, nil
+}
+
+func signaturesJSON(sigs []flow.TransactionSignature) []signatureJSON {
+	out := []signatureJSON{}
+	for _, sig := range sigs {
+		out = append(out, signatureJSON{
+			Address:   sig.Address.Hex(),
+			KeyIndex:  sig.KeyIndex,
+			Signature: hex.EncodeToString(sig.Signature),
+		})
+	}
+	return out
+}
+
+// signerAddresses returns the addresses that must sign the transaction, in role order.
+func signerAddresses(tx *flow.Transaction) []flow.Address {
+	var addresses []flow.Address
+	seen := map[flow.Address]bool{}
+	for _, a := range append(append([]flow.Address{tx.ProposalKey.Address}, tx.Authorizers...), tx.Payer) {
+		if !seen[a] {
+			seen[a] = true
+			addresses = append(addresses, a)
+		}
+	}
+	return addresses
+}
+
+// isPayloadSigner reports whether the address proposes or authorizes the transaction.
+func isPayloadSigner(tx *flow.Transaction, address flow.Address) bool {
+	if address == tx.ProposalKey.Address {
+		return true
+	}
+	for _, a := range tx.Authorizers {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSignature(sigs []flow.TransactionSignature, address flow.Address, index int) bool {
+	for _, sig := range sigs {
+		if sig.Address == address && sig.KeyIndex == index {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/rrossilli/glow/model"
+	"github.com/rrossilli/glow/tmp"
+)
+
+// ERROR_CODE_INVALID_SEQ_NUMBER is the FVM error code of a transaction whose
+// proposal key sequence number does not match the one on chain.
+const ERROR_CODE_INVALID_SEQ_NUMBER = 1007
+
+// POOL_SEQ_RETRIES is how many times Send rebuilds and resends a pooled
+// transaction that failed with a sequence number mismatch.
+const POOL_SEQ_RETRIES = 3
+
+// ProposerPool lends the proposal keys of one account to transactions built
+// concurrently, so they do not collide on a key's sequence number. Each key
+// proposes one transaction at a time, and its sequence number is tracked
+// locally instead of being fetched for every transaction.
+type ProposerPool struct {
+	client  *GlowClient
+	account model.Account
+	keys    []int
+	free    chan int // indexes of keys not proposing a transaction
+
+	mu    sync.Mutex
+	seq   map[int]uint64 // next sequence number of each key
+	stale map[int]bool   // keys whose sequence number must be fetched again
+}
+
+// NewProposerPool adds n copies of the account's key to it on chain, with
+// full weight, and returns a pool proposing with them. The account signs
+// the key additions, and pooled transactions sign with the copies.
+func (c *GlowClient) NewProposerPool(acct model.Account, n int) (*ProposerPool, error) {
+	if n < 1 {
+		return nil, &AccountError{Account: acct.Address, Err: fmt.Errorf("proposer pool needs at least one key, got %d", n)}
+	}
+
+	k, ok := acct.KeyAt(acct.Key.Index)
+	if !ok {
+		return nil, &AccountError{Account: acct.Address, Err: fmt.Errorf("no private key or signer for key index %d", acct.Key.Index)}
+	}
+	s, err := c.keySigner(acct, k)
+	if err != nil {
+		return nil, &AccountError{Account: acct.Address, Err: err}
+	}
+	pubKey := s.PublicKey()
+
+	args, err := keyArgs(pubKey, flow.AccountKeyWeightThreshold, pubKey.Algorithm(), s.HashAlgorithm())
+	if err != nil {
+		return nil, &AccountError{Account: acct.Address, Err: err}
+	}
+	args = append(args, cadence.NewInt(n))
+
+	_, err = c.NewTx([]byte(tmp.TX_ACCOUNT_KEY_ADD_COPIES), acct, args...).SignAndSend()
+	if err != nil {
+		return nil, &AccountError{Account: acct.Address, Err: err}
+	}
+
+	last, err := c.lastKeyIndex(&acct)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ProposerPool{
+		client:  c,
+		account: acct,
+		free:    make(chan int, n),
+		seq:     map[int]uint64{},
+		stale:   map[int]bool{},
+	}
+	for index := last - n + 1; index <= last; index++ {
+		copied := k
+		copied.Index = index
+		copied.Signer = s
+		copied.Weight = flow.AccountKeyWeightThreshold
+		p.account.AddKey(copied)
+
+		p.keys = append(p.keys, index)
+		p.seq[index] = 0
+		p.free <- index
+	}
+
+	return p, nil
+}
+
+// Keys returns the indexes of the pool's keys.
+func (p *ProposerPool) Keys() []int {
+	return append([]int(nil), p.keys...)
+}
+
+// Account returns the pool's account, with the pool's keys recorded.
+func (p *ProposerPool) Account() model.Account {
+	return p.account
+}
+
+// proposalLease is a pool key lent to one transaction.
+type proposalLease struct {
+	pool     *ProposerPool
+	proposer model.Account // the pool's account, proposing with the lent key
+	seq      uint64
+}
+
+// acquire waits for a free key, fetching its sequence number if it is stale.
+func (p *ProposerPool) acquire(ctx context.Context) (*proposalLease, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var index int
+	select {
+	case index = <-p.free:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a proposal key: %w", ctx.Err())
+	}
+
+	p.mu.Lock()
+	stale := p.stale[index]
+	seq := p.seq[index]
+	p.mu.Unlock()
+
+	if stale {
+		onChain, err := p.client.FlowKit.GetAccount(ctx, p.account.FlowAddress())
+		if err != nil {
+			p.free <- index
+			return nil, &AccountError{Account: p.account.Address, Err: err}
+		}
+		seq = onChain.Keys[index].SequenceNumber
+
+		p.mu.Lock()
+		p.seq[index] = seq
+		p.stale[index] = false
+		p.mu.Unlock()
+	}
+
+	return &proposalLease{
+		pool:     p,
+		proposer: p.account.WithKeyIndex(index),
+		seq:      seq,
+	}, nil
+}
+
+// release returns the key to the pool. A key whose transaction was executed
+// moves to its next sequence number; a stale key is fetched again when next
+// acquired.
+func (l *proposalLease) release(executed, stale bool) {
+	p, index := l.pool, l.proposer.Key.Index
+
+	p.mu.Lock()
+	if executed {
+		p.seq[index] = l.seq + 1
+	}
+	if stale {
+		p.stale[index] = true
+	}
+	p.mu.Unlock()
+
+	p.free <- index
+}
+
+// settle waits in the background until the transaction is executed, then
+// releases its key. The key is marked stale if the sequence number did not
+// match or the outcome cannot be known.
+func (l *proposalLease) settle(id flow.Identifier) {
+	gateway := l.pool.client.FlowKit.Gateway()
+	for {
+		res, err := gateway.GetTransactionResult(id, false)
+		if err != nil || res.Status == flow.TransactionStatusExpired {
+			l.release(false, true)
+			return
+		}
+		if res.Status >= TX_STATUS_EXECUTED {
+			mismatch := res.Error != nil && isSeqMismatch(res.Error)
+			l.release(!mismatch, mismatch)
+			return
+		}
+		time.Sleep(TX_POLL_INTERVAL)
+	}
+}
+
+// isSeqMismatch reports whether a transaction failed because of its proposal
+// key sequence number.
+func isSeqMismatch(err error) bool {
+	var ce *CadenceError
+	if !errors.As(err, &ce) {
+		ce = NewCadenceError(err)
+	}
+	return ce.Code == ERROR_CODE_INVALID_SEQ_NUMBER
+}
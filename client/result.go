@@ -0,0 +1,142 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// TxResult is the result of a sent transaction. The underlying flow result
+// is embedded, so its fields remain available; its events are accessed
+// through TransactionResult.Events.
+type TxResult struct {
+	*flow.TransactionResult
+	ComputationUsed uint64 // reported by the embedded emulator only, 0 elsewhere
+}
+
+// ID returns the transaction ID.
+func (r *TxResult) ID() flow.Identifier {
+	return r.TransactionID
+}
+
+// Events returns the events whose type is typeSuffix or ends in "."+typeSuffix,
+// e.g. "Deposit", "FlowToken.TokensDeposited" or the fully qualified type.
+func (r *TxResult) Events(typeSuffix string) []Event {
+	var events []Event
+	for _, e := range r.TransactionResult.Events {
+		if e.Type == typeSuffix || strings.HasSuffix(e.Type, "."+typeSuffix) {
+			events = append(events, Event{e})
+		}
+	}
+	return events
+}
+
+// Event returns the first event matching typeSuffix.
+// Panics if there is none; use EventE to handle the error instead.
+func (r *TxResult) Event(typeSuffix string) Event {
+	e, err := r.EventE(typeSuffix)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// EventE returns the first event matching typeSuffix.
+func (r *TxResult) EventE(typeSuffix string) (Event, error) {
+	events := r.Events(typeSuffix)
+	if len(events) == 0 {
+		return Event{}, fmt.Errorf("transaction %s emitted no %s event", r.TransactionID, typeSuffix)
+	}
+	return events[0], nil
+}
+
+// DecodeEvent decodes the first event named after the type of into, which
+// must be a pointer to a struct. For example, a struct named TokensDeposited
+// receives the first FlowToken.TokensDeposited event. See Event.Decode.
+func (r *TxResult) DecodeEvent(into any) error {
+	t := reflect.TypeOf(into)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode event: expected a pointer to a struct, got %T", into)
+	}
+
+	e, err := r.EventE(t.Elem().Name())
+	if err != nil {
+		return err
+	}
+	return e.Decode(into)
+}
+
+// Event is an event emitted by a transaction.
+type Event struct {
+	flow.Event
+}
+
+// Field returns the value of the named event field, or nil if the event has no such field.
+func (e Event) Field(name string) cadence.Value {
+	if e.Value.EventType == nil {
+		return nil
+	}
+	for i, f := range e.Value.EventType.Fields {
+		if f.Identifier == name && i < len(e.Value.Fields) {
+			return e.Value.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Decode sets the fields of the struct into points to from the event's
+// fields, as Decode does, except that every struct field must have an
+// event field. Fields are matched by their `cadence` tag, or else by name
+// ignoring case; fields tagged `cadence:"-"` are skipped.
+func (e Event) Decode(into any) error {
+	v := reflect.ValueOf(into)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode event %s: expected a pointer to a struct, got %T", e.Type, into)
+	}
+
+	d := decoder{required: true}
+	if err := d.decode(e.Value, v.Elem(), ""); err != nil {
+		return fmt.Errorf("decode event %s: %w", e.Type, err)
+	}
+	return nil
+}
+
+// computationLog records the computation used by each transaction from
+// the debug log of the embedded emulator.
+type computationLog struct {
+	mu   sync.Mutex
+	used map[string]uint64 // transaction ID to computation used
+}
+
+func newComputationLog() *computationLog {
+	return &computationLog{used: map[string]uint64{}}
+}
+
+// Write receives one JSON log entry per call.
+func (l *computationLog) Write(p []byte) (int, error) {
+	var entry struct {
+		TxID            string `json:"txID"`
+		ComputationUsed uint64 `json:"computationUsed"`
+	}
+	if json.Unmarshal(p, &entry) == nil && entry.TxID != "" {
+		l.mu.Lock()
+		l.used[entry.TxID] = entry.ComputationUsed
+		l.mu.Unlock()
+	}
+	return len(p), nil
+}
+
+// get returns the computation used by the transaction, or 0 if unknown.
+func (l *computationLog) get(id flow.Identifier) uint64 {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.used[id.String()]
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-cli/flowkit"
@@ -11,20 +10,23 @@ import (
 
 // Sc struct encapsulates Flow script execution logic.
 type Sc struct {
-	ctx    context.Context
-	script *flowkit.Script
-	client *GlowClient
+	ctx       context.Context
+	script    *flowkit.Script
+	argsErr   error // from ArgsGo, returned by Exec
+	importErr error // unresolved imports, returned by Exec
+	client    *GlowClient
 }
 
 // newSc is a utility function to create a script, private to ensure a single point of instantiation.
 func (c *GlowClient) newSc(content string, args ...cadence.Value) *Sc {
-	b := []byte(c.replaceImportAddresses(content))
+	code, err := c.replaceImportAddresses(content)
 	return &Sc{
 		script: &flowkit.Script{
-			Code: b,
+			Code: []byte(code),
 			Args: args,
 		},
-		client: c,
+		importErr: err,
+		client:    c,
 	}
 }
 
@@ -38,13 +40,31 @@ func (c *GlowClient) NewScFromString(cdc string, args ...cadence.Value) *Sc {
 	return c.newSc(cdc, args...)
 }
 
+// MustNewScFromFile creates a new script from a file like NewScFromFileE,
+// but panics if the file cannot be loaded.
+func (c *GlowClient) MustNewScFromFile(file string, args ...cadence.Value) *Sc {
+	sc, err := c.NewScFromFileE(file, args...)
+	if err != nil {
+		panic(err)
+	}
+	return sc
+}
+
 // NewScFromFile creates a new script from a file.
+//
+// Deprecated: NewScFromFile panics if the file cannot be loaded; use
+// NewScFromFileE, or MustNewScFromFile.
 func (c *GlowClient) NewScFromFile(file string, args ...cadence.Value) *Sc {
+	return c.MustNewScFromFile(file, args...)
+}
+
+// NewScFromFileE creates a new script from a file.
+func (c *GlowClient) NewScFromFileE(file string, args ...cadence.Value) (*Sc, error) {
 	cdc, err := c.CadenceFromFile(file)
 	if err != nil {
-		panic(fmt.Sprintf("Script not found at: %s", file))
+		return nil, &CadenceFileError{Path: file, Err: err}
 	}
-	return c.newSc(cdc, args...)
+	return c.newSc(cdc, args...), nil
 }
 
 // WithContext adds context to a script.
@@ -56,6 +76,7 @@ func (s *Sc) WithContext(ctx context.Context) *Sc {
 // Args sets the arguments for the script.
 func (sc *Sc) Args(args ...cadence.Value) *Sc {
 	sc.script.Args = args
+	sc.argsErr = nil
 	return sc
 }
 
@@ -67,6 +88,12 @@ func (sc *Sc) AddArg(arg cadence.Value) *Sc {
 
 // Exec executes the script at the latest block.
 func (sc *Sc) Exec() (cadence.Value, error) {
+	if sc.importErr != nil {
+		return nil, sc.importErr
+	}
+	if sc.argsErr != nil {
+		return nil, sc.argsErr
+	}
 	query := flowkit.ScriptQuery{
 		Latest: true,
 		ID:     flow.EmptyID,
@@ -77,5 +104,11 @@ func (sc *Sc) Exec() (cadence.Value, error) {
 
 // ExecWithQuery executes the script using a specific query.
 func (sc *Sc) ExecWithQuery(query *flowkit.ScriptQuery) (cadence.Value, error) {
+	if sc.importErr != nil {
+		return nil, sc.importErr
+	}
+	if sc.argsErr != nil {
+		return nil, sc.argsErr
+	}
 	return sc.client.FlowKit.ExecuteScript(sc.ctx, *sc.script, *query)
 }
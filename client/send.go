@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+)
+
+// Statuses a sent transaction can be waited for.
+const (
+	TX_STATUS_FINALIZED = flow.TransactionStatusFinalized
+	TX_STATUS_EXECUTED  = flow.TransactionStatusExecuted
+	TX_STATUS_SEALED    = flow.TransactionStatusSealed
+)
+
+// TX_POLL_INTERVAL is how often WaitFor checks a transaction's status.
+const TX_POLL_INTERVAL = 250 * time.Millisecond
+
+// TxOutcome is the outcome of a transaction sent with SendTo.
+type TxOutcome struct {
+	ID     flow.Identifier
+	Result *TxResult // nil if Err is set
+	Err    error
+}
+
+// SendAsync submits the transaction and returns its ID without waiting for
+// it to be processed; see WaitFor. Transactions not signed by Tx.Sign are
+// first checked for missing signatures, as by Send. A ProposerPool key
+// proposing the transaction is returned to the pool once it is executed, or
+// right away if submitting fails.
+func (s *SignedTx) SendAsync() (flow.Identifier, error) {
+	id, err := s.sendAsync()
+	if s.lease != nil {
+		if err != nil {
+			s.lease.release(false, true)
+		} else {
+			go s.lease.settle(id)
+		}
+		s.lease = nil
+	}
+	return id, err
+}
+
+func (s *SignedTx) sendAsync() (flow.Identifier, error) {
+	if err := s.context().Err(); err != nil {
+		return flow.EmptyID, err
+	}
+	if !s.local {
+		missing, err := s.MissingSignatures()
+		if err != nil {
+			return flow.EmptyID, err
+		}
+		if len(missing) > 0 {
+			return flow.EmptyID, &MissingSignaturesError{ID: s.FlowTransaction().ID(), Missing: missing}
+		}
+	}
+
+	sent, err := s.client.FlowKit.Gateway().SendSignedTransaction(s.FlowTransaction())
+	if err != nil {
+		return flow.EmptyID, err
+	}
+
+	s.id = sent.ID()
+	return s.id, nil
+}
+
+// WaitFor waits until the sent transaction reaches TX_STATUS_FINALIZED,
+// TX_STATUS_EXECUTED or TX_STATUS_SEALED, or the context set with
+// Tx.WithContext is done, and returns its result. A transaction that
+// failed returns a *CadenceError once executed.
+func (s *SignedTx) WaitFor(status flow.TransactionStatus) (*TxResult, error) {
+	if s.id == flow.EmptyID {
+		return nil, fmt.Errorf("transaction has not been sent")
+	}
+	if status != TX_STATUS_FINALIZED && status != TX_STATUS_EXECUTED && status != TX_STATUS_SEALED {
+		return nil, fmt.Errorf("cannot wait for transaction status %s", status)
+	}
+
+	ctx := s.context()
+	ticker := time.NewTicker(TX_POLL_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		res, err := s.client.FlowKit.Gateway().GetTransactionResult(s.id, false)
+		if err != nil {
+			return nil, err
+		}
+		if res.Status == flow.TransactionStatusExpired {
+			return nil, fmt.Errorf("transaction %s expired", s.id)
+		}
+		if res.Status >= status {
+			if res.Error != nil {
+				return nil, NewCadenceError(res.Error)
+			}
+			return &TxResult{
+				TransactionResult: res,
+				ComputationUsed:   s.client.computation.get(s.id),
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for transaction %s to be %s: %w", s.id, status, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendTo submits the transaction like SendAsync, then waits for status in
+// the background and delivers the outcome to results. Many transactions can
+// share one results channel, so a batch can be sent before any result is
+// read. Nothing is delivered if submitting fails.
+func (s *SignedTx) SendTo(results chan<- TxOutcome, status flow.TransactionStatus) (flow.Identifier, error) {
+	id, err := s.SendAsync()
+	if err != nil {
+		return id, err
+	}
+
+	go func() {
+		res, err := s.WaitFor(status)
+		results <- TxOutcome{ID: id, Result: res, Err: err}
+	}()
+	return id, nil
+}
+
+// ID returns the ID of the sent transaction, or flow.EmptyID if it has not been sent.
+func (s *SignedTx) ID() flow.Identifier {
+	return s.id
+}
+
+func (s *SignedTx) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
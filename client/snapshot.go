@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrSnapshotsUnsupported is returned when snapshots are used on a network
+	// other than the embedded emulator.
+	ErrSnapshotsUnsupported = errors.New("snapshots are only supported on the embedded emulator")
+)
+
+// Snapshot records the current state of the embedded emulator under name,
+// replacing any snapshot with the same name.
+func (c *GlowClient) Snapshot(name string) error {
+	if c.emulator == nil {
+		return ErrSnapshotsUnsupported
+	}
+
+	block, err := c.emulator.GetLatestBlock()
+	if err != nil {
+		return fmt.Errorf("snapshot %s: %w", name, err)
+	}
+
+	c.snapshotsMu.Lock()
+	defer c.snapshotsMu.Unlock()
+	c.snapshots[name] = block.Height
+
+	return nil
+}
+
+// Rollback restores the embedded emulator to the state recorded by
+// Snapshot(name). The snapshot and those taken before it stay available, so
+// a suite can deploy once, snapshot, and roll back at the start of every
+// test. Snapshots taken after it record blocks the rollback discards, so
+// they are deleted.
+func (c *GlowClient) Rollback(name string) error {
+	if c.emulator == nil {
+		return ErrSnapshotsUnsupported
+	}
+
+	c.snapshotsMu.Lock()
+	defer c.snapshotsMu.Unlock()
+
+	height, ok := c.snapshots[name]
+	if !ok {
+		return fmt.Errorf("snapshot %s does not exist", name)
+	}
+
+	block, err := c.emulator.GetLatestBlock()
+	if err != nil {
+		return fmt.Errorf("rollback to snapshot %s: %w", name, err)
+	}
+	if block.Height != height {
+		err = c.emulator.RollbackToBlockHeight(height)
+		if err != nil {
+			return fmt.Errorf("rollback to snapshot %s: %w", name, err)
+		}
+	}
+
+	for n, h := range c.snapshots {
+		if h > height {
+			delete(c.snapshots, n)
+		}
+	}
+
+	return nil
+}
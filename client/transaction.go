@@ -2,13 +2,15 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/transactions"
 	"github.com/onflow/flow-go-sdk"
-	"github.com/onflow/flow-go-sdk/crypto"
 
 	"github.com/rrossilli/glow/model"
 )
@@ -20,6 +22,9 @@ type Tx struct {
 	payer       model.Account
 	proposer    model.Account
 	authorizers []model.Account
+	pool        *ProposerPool
+	argsErr     error // from ArgsGo, returned by Build
+	importErr   error // unresolved imports, returned by Build
 	client      *GlowClient
 }
 
@@ -50,7 +55,10 @@ func (c *GlowClient) NewTx(
 	proposer model.Account,
 	args ...cadence.Value,
 ) *Tx {
-	return c.newTx(cdc, proposer, args...)
+	code, err := c.replaceImportAddresses(string(cdc))
+	tx := c.newTx([]byte(code), proposer, args...)
+	tx.importErr = err
+	return tx
 }
 
 // NewTxFromString creates a new unsigned transaction using string code.
@@ -60,21 +68,47 @@ func (c *GlowClient) NewTxFromString(
 	proposer model.Account,
 	args ...cadence.Value,
 ) *Tx {
-	return c.newTx([]byte(c.replaceImportAddresses(cdc)), proposer, args...)
+	return c.NewTx([]byte(cdc), proposer, args...)
+}
+
+// MustNewTxFromFile creates a new unsigned transaction from a file like
+// NewTxFromFileE, but panics if the file cannot be loaded.
+func (c *GlowClient) MustNewTxFromFile(
+	file string,
+	proposer model.Account,
+	args ...cadence.Value,
+) *Tx {
+	tx, err := c.NewTxFromFileE(file, proposer, args...)
+	if err != nil {
+		panic(err)
+	}
+	return tx
 }
 
 // NewTxFromFile creates a new unsigned transaction from a file.
-// Assumes proposer is also the gas payer and sole authorizer.
+//
+// Deprecated: NewTxFromFile panics if the file cannot be loaded; use
+// NewTxFromFileE, or MustNewTxFromFile.
 func (c *GlowClient) NewTxFromFile(
 	file string,
 	proposer model.Account,
 	args ...cadence.Value,
 ) *Tx {
+	return c.MustNewTxFromFile(file, proposer, args...)
+}
+
+// NewTxFromFileE creates a new unsigned transaction from a file.
+// Assumes proposer is also the gas payer and sole authorizer.
+func (c *GlowClient) NewTxFromFileE(
+	file string,
+	proposer model.Account,
+	args ...cadence.Value,
+) (*Tx, error) {
 	cdc, err := c.CadenceFromFile(file)
 	if err != nil {
-		panic(fmt.Sprintf("Transaction not found at: %s", file))
+		return nil, &CadenceFileError{Path: file, Err: err}
 	}
-	return c.newTx([]byte(cdc), proposer, args...)
+	return c.newTx([]byte(cdc), proposer, args...), nil
 }
 
 // WithContext adds context to a transaction.
@@ -86,6 +120,7 @@ func (t *Tx) WithContext(ctx context.Context) *Tx {
 // Args specifies arguments for a transaction.
 func (t *Tx) Args(args ...cadence.Value) *Tx {
 	t.script.Args = args
+	t.argsErr = nil
 	return t
 }
 
@@ -95,17 +130,25 @@ func (t *Tx) AddArg(arg cadence.Value) *Tx {
 	return t
 }
 
-// Payer specifies who pays for the transaction.
+// Payer specifies who pays for the transaction. The payer signs the
+// envelope with the key at p.Key.Index.
 func (t *Tx) Payer(p model.Account) *Tx {
 	t.payer = p
-	t.authorizers = append(t.authorizers, p)
 	return t
 }
 
-// Proposer specifies who proposes the transaction.
+// Proposer specifies who proposes the transaction. The sequence number of
+// the key at p.Key.Index is used and incremented.
 func (t *Tx) Proposer(p model.Account) *Tx {
 	t.proposer = p
-	t.authorizers = append(t.authorizers, p)
+	return t
+}
+
+// ProposerPool makes Sign propose the transaction with a key lent by p
+// instead of the proposer's key. The key is returned to the pool once the
+// transaction is executed, or if it cannot be signed or sent.
+func (t *Tx) ProposerPool(p *ProposerPool) *Tx {
+	t.pool = p
 	return t
 }
 
@@ -121,55 +164,124 @@ func (t *Tx) AddAuthorizer(a model.Account) *Tx {
 	return t
 }
 
+// SignedTx is a built transaction and the signatures added to it so far.
 type SignedTx struct {
 	ctx    context.Context
 	flowTx *transactions.Transaction
 	client *GlowClient
+	local  bool            // signed by Tx.Sign with every required key
+	id     flow.Identifier // set once sent
+	tx     *Tx             // rebuilt if a pooled proposal key was out of sync
+	lease  *proposalLease  // set if proposed with a ProposerPool key
 }
 
-// Create new crypto signer
-func (c *GlowClient) newInMemorySigner(privKey string) (crypto.Signer, error) {
-	pk, err := c.NewPrivateKeyFromHex(privKey)
-	if err != nil {
-		return nil, err
+// FlowTransaction returns the signed flow transaction.
+func (s *SignedTx) FlowTransaction() *flow.Transaction {
+	return s.flowTx.FlowTransaction()
+}
+
+// signingKey is an account key that signs a transaction.
+type signingKey struct {
+	address flow.Address
+	index   int
+}
+
+// Sign builds the transaction and signs it following Flow's signing rules.
+// Each role signs with its account's signing keys (see
+// model.Account.SigningKeyIndexes), and the proposer also with its proposal
+// key. The proposer and authorizers sign the payload, unless they are the
+// payer, whose keys sign the envelope. An account key is used once however
+// many roles it has. With a ProposerPool, Sign first waits for a free
+// pool key to propose with.
+func (t *Tx) Sign() (*SignedTx, error) {
+	proposer := t.proposer
+	var lease *proposalLease
+	if t.pool != nil {
+		var err error
+		if lease, err = t.pool.acquire(t.ctx); err != nil {
+			return nil, err
+		}
+		proposer = lease.proposer
 	}
 
-	signer, err := crypto.NewInMemorySigner(pk, c.HashAlgo)
+	signedTx, err := t.sign(proposer, lease)
 	if err != nil {
+		if lease != nil {
+			lease.release(false, false)
+		}
 		return nil, err
 	}
-
-	return signer, nil
+	return signedTx, nil
 }
 
-// Sign tx with key at index 0. Use SignTxWithKey to specify key index
-func (t *Tx) Sign() (*SignedTx, error) {
+func (t *Tx) sign(proposer model.Account, lease *proposalLease) (*SignedTx, error) {
+	signedTx, err := t.build(proposer, lease)
+	if err != nil {
+		return nil, err
+	}
+	signedTx.local = true
 
-	// map to slice of crypto signers
-	var signers []crypto.Signer
+	type role struct {
+		account model.Account
+		keys    []int
+	}
+	roles := []role{
+		{t.payer, t.payer.SigningKeyIndexes()},
+		{proposer, append([]int{proposer.Key.Index}, proposer.SigningKeyIndexes()...)},
+	}
 	for _, a := range t.authorizers {
-		s, err := t.client.newInMemorySigner(a.PrivKey)
-		if err != nil {
-			return nil, err
+		roles = append(roles, role{a, a.SigningKeyIndexes()})
+	}
+
+	payer := t.payer.FlowAddress()
+	signed := map[signingKey]bool{}
+
+	// payload signatures come first, as the envelope signs over them
+	for _, envelope := range []bool{false, true} {
+		for _, r := range roles {
+			for _, index := range r.keys {
+				key := signingKey{address: r.account.FlowAddress(), index: index}
+				if signed[key] || (key.address == payer) != envelope {
+					continue
+				}
+				signed[key] = true
+
+				if err := t.client.signWithKey(signedTx.FlowTransaction(), r.account, key, envelope); err != nil {
+					return nil, &AccountError{Account: r.account.Address, Err: err}
+				}
+			}
 		}
-		signers = append(signers, s)
 	}
 
-	// map to slice of flow addresses
-	var addresses []flow.Address
-	for _, a := range t.authorizers {
-		addresses = append(addresses, a.FlowAddress())
+	return signedTx, nil
+}
+
+// Build builds the transaction without signing it, fetching the proposal
+// key's sequence number. Accounts need no keys to build; sign the result
+// with SignedTx.SignWith, or export it for the other parties to sign.
+func (t *Tx) Build() (*SignedTx, error) {
+	return t.build(t.proposer, nil)
+}
+
+// build builds the transaction proposed by proposer, with the pool's
+// sequence number of the lent key if leased.
+func (t *Tx) build(proposer model.Account, lease *proposalLease) (*SignedTx, error) {
+	if t.importErr != nil {
+		return nil, t.importErr
+	}
+	if t.argsErr != nil {
+		return nil, t.argsErr
 	}
 
 	var txAddresses = transactions.AddressesRoles{
-		Proposer:    t.proposer.FlowAddress(),
+		Proposer:    proposer.FlowAddress(),
 		Payer:       t.payer.FlowAddress(),
 		Authorizers: model.FlowAddressesFromAccounts(t.authorizers),
 	}
 
 	flowTx, err := t.client.FlowKit.BuildTransaction(t.ctx,
 		txAddresses,
-		0,
+		proposer.Key.Index,
 		t.script,
 		t.client.gasLimit,
 	)
@@ -177,46 +289,62 @@ func (t *Tx) Sign() (*SignedTx, error) {
 		return nil, err
 	}
 
-	// sign transaction with each signer
-	for i := len(addresses) - 1; i >= 0; i-- {
-		signerAddress := addresses[i]
-		signer := signers[i]
-
-		if i == 0 {
-			err := flowTx.FlowTransaction().SignEnvelope(signerAddress, 0, signer)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			err := flowTx.FlowTransaction().SignPayload(signerAddress, 0, signer)
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	return &SignedTx{
+	signedTx := &SignedTx{
 		ctx:    t.ctx,
 		flowTx: flowTx,
 		client: t.client,
-	}, err
+	}
+	if lease != nil {
+		signedTx.FlowTransaction().SetProposalKey(proposer.FlowAddress(), proposer.Key.Index, lease.seq)
+		signedTx.tx = t
+		signedTx.lease = lease
+	}
+	return signedTx, nil
 }
 
-// Send a signed Transaction
-func (signedTx *SignedTx) Send() (*flow.TransactionResult, error) {
-	_, res, err := signedTx.client.FlowKit.SendSignedTransaction(signedTx.ctx, signedTx.flowTx)
+// signWithKey adds the payload or envelope signature of an account key.
+func (c *GlowClient) signWithKey(tx *flow.Transaction, a model.Account, key signingKey, envelope bool) error {
+	k, ok := a.KeyAt(key.index)
+	if !ok {
+		return fmt.Errorf("no private key or signer for key index %d", key.index)
+	}
+
+	signer, err := c.keySigner(a, k)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if res.Error != nil {
-		return nil, res.Error
+
+	if envelope {
+		return tx.SignEnvelope(key.address, key.index, signer)
 	}
+	return tx.SignPayload(key.address, key.index, signer)
+}
 
-	return res, nil
+// Send a signed Transaction and wait until it is sealed. Transactions not
+// signed by Tx.Sign are first checked for missing signatures; see
+// MissingSignatures. A transaction proposed with a ProposerPool key that
+// fails with a sequence number mismatch is signed and sent again, up to
+// POOL_SEQ_RETRIES times.
+func (signedTx *SignedTx) Send() (*TxResult, error) {
+	for retries := 0; ; retries++ {
+		if _, err := signedTx.SendAsync(); err != nil {
+			return nil, err
+		}
+		res, err := signedTx.WaitFor(TX_STATUS_SEALED)
+		if err == nil || signedTx.tx == nil || retries == POOL_SEQ_RETRIES || !isSeqMismatch(err) {
+			return res, err
+		}
+
+		retry, err := signedTx.tx.Sign()
+		if err != nil {
+			return nil, err
+		}
+		*signedTx = *retry
+	}
 }
 
 // Sign and send a transaction
-func (tx *Tx) SignAndSend() (*flow.TransactionResult, error) {
+func (tx *Tx) SignAndSend() (*TxResult, error) {
 	signedTx, err := tx.Sign()
 	if err != nil {
 		return nil, err
@@ -229,3 +357,36 @@ func (tx *Tx) SignAndSend() (*flow.TransactionResult, error) {
 
 	return txRes, nil
 }
+
+// SignAndSendExpectFailure signs and sends a transaction that is expected to
+// fail with a Cadence error whose message contains substr, and returns the
+// parsed error. It returns an error if the transaction succeeds or fails
+// with a different message, along with the Cadence error in the latter case.
+func (tx *Tx) SignAndSendExpectFailure(substr string) (*CadenceError, error) {
+	return tx.signAndSendExpectFailure(fmt.Sprintf("%q", substr), func(msg string) bool {
+		return strings.Contains(msg, substr)
+	})
+}
+
+// SignAndSendExpectFailureRegexp is like SignAndSendExpectFailure, matching
+// the Cadence error message against re.
+func (tx *Tx) SignAndSendExpectFailureRegexp(re *regexp.Regexp) (*CadenceError, error) {
+	return tx.signAndSendExpectFailure("/"+re.String()+"/", re.MatchString)
+}
+
+func (tx *Tx) signAndSendExpectFailure(pattern string, match func(string) bool) (*CadenceError, error) {
+	res, err := tx.SignAndSend()
+	if err == nil {
+		return nil, fmt.Errorf("transaction %s succeeded, expected a failure matching %s", res.ID(), pattern)
+	}
+
+	var ce *CadenceError
+	if !errors.As(err, &ce) {
+		return nil, err
+	}
+	if !match(ce.Message) {
+		return ce, fmt.Errorf("expected a failure matching %s, got: %w", pattern, ce)
+	}
+
+	return ce, nil
+}
@@ -0,0 +1,79 @@
+// Command glow is the Glow command line tool.
+//
+//	glow gen [-root dir] [-out file] [-pkg name] [-check]
+//
+// gen writes Go bindings for the project's transactions and scripts; see
+// package gen. Add it as a go:generate directive to regenerate the bindings
+// with go generate whenever a Cadence signature changes, and use -check in
+// CI to fail when they are out of date.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rrossilli/glow/gen"
+)
+
+const USAGE = `usage: glow <command> [flags]
+
+commands:
+  gen    generate Go bindings for transactions and scripts
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, USAGE)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "gen":
+		if err := runGen(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			if errors.Is(err, gen.ErrStale) {
+				os.Exit(1)
+			}
+			os.Exit(2)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "glow: unknown command %q\n\n%s", os.Args[1], USAGE)
+		os.Exit(2)
+	}
+}
+
+func runGen(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	root := fs.String("root", os.Getenv("GLOW_ROOT"), "project root holding flow.json (default $GLOW_ROOT)")
+	out := fs.String("out", "glow_gen.go", "file to write the bindings to")
+	pkg := fs.String("pkg", "", "package name of the bindings (default the name of out's directory)")
+	txDir := fs.String("tx", gen.DEFAULT_TX_DIR, "transactions folder under root")
+	scriptDir := fs.String("script", gen.DEFAULT_SCRIPT_DIR, "scripts folder under root")
+	check := fs.Bool("check", false, "report whether out is up to date instead of writing it")
+	fs.Parse(args)
+
+	if *root == "" {
+		*root = "."
+	}
+	if *pkg == "" {
+		dir, err := filepath.Abs(filepath.Dir(*out))
+		if err != nil {
+			return err
+		}
+		*pkg = filepath.Base(dir)
+	}
+
+	cfg := gen.Config{Root: *root, Package: *pkg, TxDir: *txDir, ScriptDir: *scriptDir}
+	if *check {
+		return gen.Check(cfg, *out)
+	}
+
+	src, err := gen.Generate(cfg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(*out, src, 0644)
+}
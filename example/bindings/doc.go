@@ -0,0 +1,5 @@
+// Package bindings holds the Go bindings of the example project's
+// transactions and scripts, generated by glow gen.
+package bindings
+
+//go:generate go run github.com/rrossilli/glow/cmd/glow gen -root .. -out glow_gen.go
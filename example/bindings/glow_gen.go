@@ -0,0 +1,176 @@
+// Code generated by glow gen. DO NOT EDIT.
+
+package bindings
+
+import (
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/model"
+)
+
+// AccountCreate returns the transaction in /transaction/account_create.cdc with its arguments set.
+// signer proposes, pays for and authorizes it.
+// Panics if the file cannot be loaded.
+func AccountCreate(c *client.GlowClient, signer model.Account, publicKeys []string, contracts map[string]string) *client.Tx {
+	return c.MustNewTxFromFile("/transaction/account_create.cdc", signer,
+		func(xs []string) cadence.Value {
+			vs := make([]cadence.Value, len(xs))
+			for i, x := range xs {
+				vs[i] = cadence.String(x)
+			}
+			return cadence.NewArray(vs)
+		}(publicKeys),
+		func(m map[string]string) cadence.Value {
+			pairs := make([]cadence.KeyValuePair, 0, len(m))
+			for k, v := range m {
+				pairs = append(pairs, cadence.KeyValuePair{Key: cadence.String(k), Value: cadence.String(v)})
+			}
+			return cadence.NewDictionary(pairs)
+		}(contracts),
+	)
+}
+
+// AccountSetup returns the transaction in /transaction/account_setup.cdc.
+// signer proposes, pays for and authorizes it.
+// Panics if the file cannot be loaded.
+func AccountSetup(c *client.GlowClient, signer model.Account) *client.Tx {
+	return c.MustNewTxFromFile("/transaction/account_setup.cdc", signer)
+}
+
+// AccountSetupRoyalty returns the transaction in /transaction/account_setup_royalty.cdc with its arguments set.
+// signer proposes, pays for and authorizes it.
+// Panics if the file cannot be loaded.
+func AccountSetupRoyalty(c *client.GlowClient, signer model.Account, vaultPath cadence.Path) *client.Tx {
+	return c.MustNewTxFromFile("/transaction/account_setup_royalty.cdc", signer,
+		vaultPath,
+	)
+}
+
+// ContractDeploy returns the transaction in /transaction/contract_deploy.cdc with its arguments set.
+// signer proposes, pays for and authorizes it.
+// Panics if the file cannot be loaded.
+func ContractDeploy(c *client.GlowClient, signer model.Account, name string, code string) *client.Tx {
+	return c.MustNewTxFromFile("/transaction/contract_deploy.cdc", signer,
+		cadence.String(name),
+		cadence.String(code),
+	)
+}
+
+// ContractRemove returns the transaction in /transaction/contract_remove.cdc with its arguments set.
+// signer proposes, pays for and authorizes it.
+// Panics if the file cannot be loaded.
+func ContractRemove(c *client.GlowClient, signer model.Account, name string) *client.Tx {
+	return c.MustNewTxFromFile("/transaction/contract_remove.cdc", signer,
+		cadence.String(name),
+	)
+}
+
+// ContractUpdate returns the transaction in /transaction/contract_update.cdc with its arguments set.
+// signer proposes, pays for and authorizes it.
+// Panics if the file cannot be loaded.
+func ContractUpdate(c *client.GlowClient, signer model.Account, name string, code string) *client.Tx {
+	return c.MustNewTxFromFile("/transaction/contract_update.cdc", signer,
+		cadence.String(name),
+		cadence.String(code),
+	)
+}
+
+// FlowTransfer returns the transaction in /transaction/flow_transfer.cdc with its arguments set.
+// signer proposes, pays for and authorizes it.
+// Panics if the file cannot be loaded.
+func FlowTransfer(c *client.GlowClient, signer model.Account, amount cadence.UFix64, recipient flow.Address) *client.Tx {
+	return c.MustNewTxFromFile("/transaction/flow_transfer.cdc", signer,
+		amount,
+		cadence.Address(recipient),
+	)
+}
+
+// ForwarderInit returns the transaction in /transaction/forwarder_init.cdc.
+// acct proposes, pays for and authorizes it.
+// Panics if the file cannot be loaded.
+func ForwarderInit(c *client.GlowClient, acct model.Account) *client.Tx {
+	return c.MustNewTxFromFile("/transaction/forwarder_init.cdc", acct)
+}
+
+// NFTMint returns the transaction in /transaction/nft_mint.cdc with its arguments set.
+// signer proposes, pays for and authorizes it.
+// Panics if the file cannot be loaded.
+func NFTMint(c *client.GlowClient, signer model.Account, recipient flow.Address, name string, description string, thumbnail string, cuts []cadence.UFix64, royaltyDescriptions []string, royaltyBeneficiaries []flow.Address) *client.Tx {
+	return c.MustNewTxFromFile("/transaction/nft_mint.cdc", signer,
+		cadence.Address(recipient),
+		cadence.String(name),
+		cadence.String(description),
+		cadence.String(thumbnail),
+		func(xs []cadence.UFix64) cadence.Value {
+			vs := make([]cadence.Value, len(xs))
+			for i, x := range xs {
+				vs[i] = x
+			}
+			return cadence.NewArray(vs)
+		}(cuts),
+		func(xs []string) cadence.Value {
+			vs := make([]cadence.Value, len(xs))
+			for i, x := range xs {
+				vs[i] = cadence.String(x)
+			}
+			return cadence.NewArray(vs)
+		}(royaltyDescriptions),
+		func(xs []flow.Address) cadence.Value {
+			vs := make([]cadence.Value, len(xs))
+			for i, x := range xs {
+				vs[i] = cadence.Address(x)
+			}
+			return cadence.NewArray(vs)
+		}(royaltyBeneficiaries),
+	)
+}
+
+// NFTTransfer returns the transaction in /transaction/nft_transfer.cdc with its arguments set.
+// signer proposes, pays for and authorizes it.
+// Panics if the file cannot be loaded.
+func NFTTransfer(c *client.GlowClient, signer model.Account, recipient flow.Address, withdrawID uint64) *client.Tx {
+	return c.MustNewTxFromFile("/transaction/nft_transfer.cdc", signer,
+		cadence.Address(recipient),
+		cadence.UInt64(withdrawID),
+	)
+}
+
+// FlowBalance executes the script in /script/flow_balance.cdc.
+func FlowBalance(c *client.GlowClient, account flow.Address) (result cadence.UFix64, err error) {
+	sc, err := c.NewScFromFileE("/script/flow_balance.cdc",
+		cadence.Address(account),
+	)
+	if err != nil {
+		return result, err
+	}
+	res, err := sc.Exec()
+	if err != nil {
+		return result, err
+	}
+	defer recoverResult("FlowBalance", &err)
+	return res.(cadence.UFix64), nil
+}
+
+// NFTBorrow executes the script in /script/nft_borrow.cdc.
+func NFTBorrow(c *client.GlowClient, address flow.Address, id uint64) error {
+	sc, err := c.NewScFromFileE("/script/nft_borrow.cdc",
+		cadence.Address(address),
+		cadence.UInt64(id),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = sc.Exec()
+	return err
+}
+
+// recoverResult turns a script result of an unexpected type into an error.
+func recoverResult(name string, err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%s: unexpected result, regenerate the bindings: %v", name, r)
+	}
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/rrossilli/glow/model"
+	"github.com/rrossilli/glow/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAccountKeyLifecycle verifies adding, revoking and rotating account keys.
+func TestAccountKeyLifecycle(t *testing.T) {
+	g := glowtest.New(t)
+	svc := g.Client.SvcAcct
+	acct := g.Account("test")
+
+	noop := func(a model.Account) error {
+		_, err := g.Client.NewTxFromString(`transaction { prepare(signer: AuthAccount) {} }`, a).
+			Payer(svc).
+			SignAndSend()
+		return err
+	}
+
+	// add a key and sign with it
+	key := newKey(t, crypto.ECDSA_secp256k1, crypto.SHA2_256, 1000)
+	pk, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_secp256k1, util.RemoveHexPrefix(key.PrivateKey))
+	require.NoError(t, err)
+	index, err := g.Client.AddKey(&acct, pk.PublicKey(), 1000, crypto.ECDSA_secp256k1, crypto.SHA2_256)
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+	require.Len(t, acct.Keys, 2)
+	assert.Empty(t, acct.Keys[1].PrivateKey)
+
+	acct.Keys[1].PrivateKey = key.PrivateKey
+	require.NoError(t, noop(acct.WithKeyIndex(1)))
+
+	// revoke the original key; it can no longer sign
+	require.NoError(t, g.Client.RevokeKey(&acct, 0))
+	assert.True(t, acct.Keys[0].Revoked)
+	assert.Error(t, noop(acct.WithKeyIndex(0)))
+	require.NoError(t, noop(acct.WithKeyIndex(1)))
+
+	// rotate the current key
+	signer := acct.WithKeyIndex(1)
+	require.NoError(t, g.Client.RotateKey(&signer))
+	assert.Equal(t, 2, signer.Key.Index)
+	assert.Equal(t, crypto.ECDSA_secp256k1.String(), signer.Key.SigAlgo)
+	require.NoError(t, noop(signer))
+	assert.Error(t, noop(signer.WithKeyIndex(1)))
+
+	onChain, err := g.Client.FlowKit.GetAccount(context.Background(), acct.FlowAddress())
+	require.NoError(t, err)
+	require.Len(t, onChain.Keys, 3)
+	assert.True(t, onChain.Keys[0].Revoked)
+	assert.True(t, onChain.Keys[1].Revoked)
+	assert.False(t, onChain.Keys[2].Revoked)
+	assert.Equal(t, crypto.SHA2_256, onChain.Keys[2].HashAlgo)
+}
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	EMULATOR_SVC_KEY  = "3a63ae4f8fffacd89d1b574d87fe448a0f848da7d0a45c04b60744b1c3905a14"
+	EMULATOR_ACCT_KEY = "4a4f7a1d07b441135489823f1bcdc27ba607c1916b3b182a2b7ee91cf11eb5f6"
+)
+
+// emulatorProject returns a flow.json config with a service account and the
+// given accounts (name to address).
+func emulatorProject(accounts map[string]string) map[string]interface{} {
+	accts := map[string]interface{}{
+		"emulator-svc": map[string]string{"address": "f8d6e0586b0a20c7", "key": EMULATOR_SVC_KEY},
+	}
+	for name, addr := range accounts {
+		accts[name] = map[string]string{"address": addr, "key": EMULATOR_ACCT_KEY}
+	}
+
+	return map[string]interface{}{
+		"emulators": map[string]interface{}{"default": map[string]interface{}{"port": 3569, "serviceAccount": "emulator-svc"}},
+		"networks":  map[string]string{"emulator": "127.0.0.1:3569"},
+		"accounts":  accts,
+	}
+}
+
+// writeEmulatorProject writes an emulatorProject to a temporary root and returns the root.
+func writeEmulatorProject(t *testing.T, accounts map[string]string) string {
+	return WriteProject(t, emulatorProject(accounts), nil)
+}
+
+// TestEmulatorAccountOrder verifies that accounts are ordered by the emulator's address sequence.
+func TestEmulatorAccountOrder(t *testing.T) {
+	// Twelve accounts, defined out of order.
+	accounts := map[string]string{}
+	for i := 12; i >= 1; i-- {
+		accounts[fmt.Sprintf("emulator-%02d", i)] = model.EmulatorAddress(uint(4 + i)).Hex()
+	}
+	fj, err := model.FlowJSON{}.FromFile(path.Join(writeEmulatorProject(t, accounts), "flow.json"))
+	require.NoError(t, err)
+
+	names, err := fj.AccountNamesSorted("emulator")
+	require.NoError(t, err)
+	require.Len(t, names, 13)
+	assert.Equal(t, "emulator-svc", names[0])
+	assert.Equal(t, "emulator-01", names[1])
+	assert.Equal(t, "emulator-12", names[12])
+
+	// A gap in the sequence names the missing address.
+	gap := writeEmulatorProject(t, map[string]string{"emulator-second": model.EmulatorAddress(6).Hex()})
+	fj, err = model.FlowJSON{}.FromFile(path.Join(gap, "flow.json"))
+	require.NoError(t, err)
+	_, err = fj.AccountNamesSorted("emulator")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "0x"+model.EmulatorAddress(5).Hex())
+
+	// Addresses from another chain are rejected.
+	foreign := writeEmulatorProject(t, map[string]string{"emulator-testnet": "9a0766d93b6608b7"})
+	fj, err = model.FlowJSON{}.FromFile(path.Join(foreign, "flow.json"))
+	require.NoError(t, err)
+	_, err = fj.AccountNamesSorted("emulator")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not an emulator address")
+}
+
+// TestCreateManyEmulatorAccounts verifies that more accounts than fit the former hardcoded list are created.
+func TestCreateManyEmulatorAccounts(t *testing.T) {
+	accounts := map[string]string{}
+	for i := 1; i <= 12; i++ {
+		accounts[fmt.Sprintf("emulator-%02d", i)] = model.EmulatorAddress(uint(4 + i)).Hex()
+	}
+	root := writeEmulatorProject(t, accounts)
+
+	c, err := client.NewGlowClientBuilder(client.NETWORK_EMBEDDED, root, 0).StartE()
+	require.NoError(t, err)
+
+	// The last account exists on chain at its configured address.
+	last := c.FlowJSON.Account("emulator-12")
+	acct, err := c.GetAccount(last.Address)
+	require.NoError(t, err)
+	assert.Equal(t, last.FlowAddress(), acct.Address)
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const SC_ARGS_GO = `
+import MetadataViews from 0xMetadataViews
+
+pub fun main(
+	to: Address,
+	amounts: {String: UFix64},
+	ids: [UInt64],
+	memo: String?,
+	big: UInt128,
+	editions: MetadataViews.Editions,
+	path: StoragePath
+): [String] {
+	let edition = editions.infoList[0]
+	return [
+		to.toString(),
+		amounts["a"]!.toString(),
+		ids[1].toString(),
+		memo ?? "none",
+		big.toString(),
+		edition.name!.concat("/").concat(edition.number.toString()).concat("/").concat(edition.max!.toString()),
+		path.toString()
+	]
+}`
+
+type edition struct {
+	Label  string `cadence:"name"`
+	Number uint64
+	Max    *uint64
+}
+
+// TestArgsGo verifies converting Go values to the declared parameter types.
+func TestArgsGo(t *testing.T) {
+	g := glowtest.New(t)
+	user := g.Account("test")
+
+	max := uint64(100)
+	res, err := g.Client.NewScFromString(SC_ARGS_GO).ArgsGo(
+		user,
+		map[string]float64{"a": 1.5},
+		[]int{7, 8},
+		nil,
+		new(big.Int).Lsh(big.NewInt(1), 100),
+		struct{ InfoList []edition }{[]edition{{"Series A", 24, &max}}},
+		"/storage/flowTokenVault",
+	).Exec()
+	require.NoError(t, err)
+
+	var got []string
+	for _, v := range res.(cadence.Array).Values {
+		got = append(got, string(v.(cadence.String)))
+	}
+	assert.Equal(t, []string{
+		user.CadenceAddress().String(),
+		"1.50000000",
+		"8",
+		"none",
+		"1267650600228229401496703205376",
+		"Series A/24/100",
+		"/storage/flowTokenVault",
+	}, got)
+
+	// a transfer with a float amount and an account as the recipient
+	_, err = g.Client.NewTxFromFile(TxPath("flow_transfer"), g.Client.SvcAcct).
+		ArgsGo("2.5", user).
+		SignAndSend()
+	require.NoError(t, err)
+}
+
+// TestArgsGoErrors verifies the errors of arguments that do not match their parameters.
+func TestArgsGoErrors(t *testing.T) {
+	g := glowtest.New(t)
+	svc := g.Client.SvcAcct
+	code := `transaction(amount: UFix64, count: UInt8, to: Address) {}`
+
+	_, err := g.Client.NewTxFromString(code, svc).ArgsGo(1.0, 1).Build()
+	assert.ErrorContains(t, err, "expected 3 arguments, got 2")
+
+	for _, c := range []struct {
+		args  []interface{}
+		index int
+		msg   string
+	}{
+		{[]interface{}{-1.0, 1, svc}, 0, "-1.0 is negative"},
+		{[]interface{}{1.0, 300, svc}, 1, "300 is out of range for UInt8"},
+		{[]interface{}{1.0, "x", svc}, 1, "cannot convert string to UInt8"},
+		{[]interface{}{1.0, 1, 42}, 2, "cannot convert int to Address"},
+		{[]interface{}{1.0, 1, "0xzz"}, 2, `invalid address "0xzz"`},
+	} {
+		_, err := g.Client.NewTxFromString(code, svc).ArgsGo(c.args...).Sign()
+		var argErr *client.ArgError
+		require.ErrorAs(t, err, &argErr, c.msg)
+		assert.Equal(t, c.index, argErr.Index)
+		assert.ErrorContains(t, err, c.msg)
+	}
+
+	_, err = g.Client.NewScFromString(SC_ARGS_GO).ArgsGo(
+		svc, map[string]float64{}, []int{}, "memo", 1,
+		struct{ InfoList []struct{ Number uint64 } }{InfoList: make([]struct{ Number uint64 }, 1)},
+		"/storage/x",
+	).Exec()
+	assert.ErrorContains(t, err, "has no field for MetadataViews.Edition.name")
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCadenceError verifies that failed transactions are parsed into CadenceErrors.
+func TestCadenceError(t *testing.T) {
+	g := glowtest.New(t)
+	svc := g.Client.SvcAcct
+
+	// a failed pre-condition in the transaction itself
+	ce, err := g.Client.NewTxFromString(
+		`transaction { prepare(s: AuthAccount) { pre { 1 == 2: "one is not two" } } }`, svc,
+	).SignAndSendExpectFailure("one is not two")
+	require.NoError(t, err)
+	assert.Equal(t, 1101, ce.Code)
+	assert.Equal(t, client.CONDITION_PRE, ce.Condition)
+	assert.Equal(t, "pre-condition failed: one is not two", ce.Message)
+	assert.Equal(t, 1, ce.Line)
+	assert.Equal(t, 46, ce.Column)
+
+	// a panic inside a contract called by the transaction
+	ce = g.SendExpectFailure(g.Client.NewTxFromString(`
+import ExampleNFT from 0xExampleNFT
+
+transaction {
+	prepare(s: AuthAccount) {
+		let c <- ExampleNFT.createEmptyCollection()
+		let n <- c.withdraw(withdrawID: 5)
+		destroy n
+		destroy c
+	}
+}`, svc), "missing NFT")
+	assert.Equal(t, "missing NFT", ce.Panic)
+	assert.Empty(t, ce.Condition)
+	assert.Equal(t, "f8d6e0586b0a20c7.ExampleNFT", ce.Location)
+	assert.Equal(t, 201, ce.Line)
+}
+
+// TestSignAndSendExpectFailure verifies matching of expected failures.
+func TestSignAndSendExpectFailure(t *testing.T) {
+	g := glowtest.New(t)
+	collector := g.Account("test")
+	mint := func() *client.Tx {
+		return g.Tx("nft_mint", collector,
+			collector.CadenceAddress(),
+			cadence.String("name"),
+			cadence.String("description"),
+			cadence.String("thumbnail"),
+			cadence.NewArray([]cadence.Value{}),
+			cadence.NewArray([]cadence.Value{}),
+			cadence.NewArray([]cadence.Value{}),
+		)
+	}
+
+	// only the service account holds the minter
+	ce := g.SendExpectFailure(mint(), "Could not borrow a reference to the NFT minter")
+	assert.Equal(t, "Could not borrow a reference to the NFT minter", ce.Panic)
+
+	ce, err := mint().SignAndSendExpectFailureRegexp(regexp.MustCompile(`^panic: Could not borrow .* minter$`))
+	require.NoError(t, err)
+	assert.NotNil(t, ce)
+
+	// a different failure returns the parsed error alongside the mismatch
+	ce, err = mint().SignAndSendExpectFailure("insufficient balance")
+	assert.Error(t, err)
+	require.NotNil(t, ce)
+	assert.Contains(t, ce.Panic, "NFT minter")
+
+	// success is an error
+	_, err = g.Client.NewTxFromString(`transaction {}`, g.Client.SvcAcct).SignAndSendExpectFailure("anything")
+	assert.ErrorContains(t, err, "succeeded")
+}
@@ -0,0 +1,150 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const SC_SUMMARY = `
+import MetadataViews from 0xMetadataViews
+
+pub struct Summary {
+	pub let owner: Address
+	pub let balance: UFix64
+	pub let scores: {String: Int}
+	pub let editions: MetadataViews.Editions
+	pub let note: String?
+
+	init(owner: Address) {
+		self.owner = owner
+		self.balance = 12.5
+		self.scores = {"alice": 3}
+		self.editions = MetadataViews.Editions([
+			MetadataViews.Edition(name: "Series A", number: 24, max: 100),
+			MetadataViews.Edition(name: nil, number: 1, max: nil)
+		])
+		self.note = nil
+	}
+}
+
+pub fun main(owner: Address): Summary {
+	return Summary(owner: owner)
+}`
+
+type editionView struct {
+	Label  *string `cadence:"name"`
+	Number uint32
+	Max    *uint64
+}
+
+type summary struct {
+	Owner    flow.Address
+	Balance  float64
+	Scores   map[string]int
+	Editions struct {
+		InfoList []editionView
+	}
+	Note *string
+}
+
+// TestExecInto verifies decoding a script's struct result into a Go struct.
+func TestExecInto(t *testing.T) {
+	g := glowtest.New(t)
+	user := g.Account("test")
+	sc := func() *client.Sc {
+		return g.Client.NewScFromString(SC_SUMMARY, user.CadenceAddress())
+	}
+
+	var out summary
+	require.NoError(t, sc().ExecIntoStrict(&out))
+	assert.Equal(t, user.FlowAddress(), out.Owner)
+	assert.Equal(t, 12.5, out.Balance)
+	assert.Equal(t, map[string]int{"alice": 3}, out.Scores)
+	require.Len(t, out.Editions.InfoList, 2)
+	assert.Equal(t, "Series A", *out.Editions.InfoList[0].Label)
+	assert.Equal(t, uint32(24), out.Editions.InfoList[0].Number)
+	assert.Equal(t, uint64(100), *out.Editions.InfoList[0].Max)
+	assert.Nil(t, out.Editions.InfoList[1].Label)
+	assert.Nil(t, out.Note)
+
+	// lenient decoding ignores fields missing on either side
+	var partial struct {
+		Balance string
+		Extra   int
+	}
+	require.NoError(t, sc().ExecInto(&partial))
+	assert.Equal(t, "12.50000000", partial.Balance)
+	err := sc().ExecIntoStrict(&partial)
+	var decodeErr *client.DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, ".owner", decodeErr.Path)
+
+	// errors name the field that failed
+	var badType struct{ Scores map[string]string }
+	err = sc().ExecInto(&badType)
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, `.scores["alice"]`, decodeErr.Path)
+	assert.ErrorContains(t, err, "cannot decode Int into string")
+
+	assert.Error(t, sc().ExecInto(out))
+}
+
+// TestDecodeResource verifies that strict decoding does not require a resource's uuid.
+func TestDecodeResource(t *testing.T) {
+	v := cadence.NewResource([]cadence.Value{cadence.UInt64(7), cadence.String("sword"), cadence.UInt16(300)}).
+		WithType(&cadence.ResourceType{
+			QualifiedIdentifier: "Item",
+			Fields: []cadence.Field{
+				{Identifier: "uuid"}, {Identifier: "name"}, {Identifier: "power"},
+			},
+		})
+
+	var item struct {
+		Name  string
+		Power int
+	}
+	require.NoError(t, client.DecodeStrict(v, &item))
+	assert.Equal(t, "sword", item.Name)
+	assert.Equal(t, 300, item.Power)
+
+	var small struct{ Power uint8 }
+	err := client.Decode(v, &small)
+	var decodeErr *client.DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, ".power", decodeErr.Path)
+	assert.ErrorContains(t, err, "300 overflows uint8")
+}
+
+// TestDecodeNilInterface verifies decoding nil optionals into interface{} values.
+func TestDecodeNilInterface(t *testing.T) {
+	var v interface{} = "previous"
+	require.NoError(t, client.Decode(cadence.NewOptional(nil), &v))
+	assert.Nil(t, v)
+
+	arr := cadence.NewArray([]cadence.Value{cadence.NewOptional(cadence.String("a")), cadence.NewOptional(nil)})
+	var values []interface{}
+	require.NoError(t, client.Decode(arr, &values))
+	assert.Equal(t, []interface{}{"a", nil}, values)
+
+	dict := cadence.NewDictionary([]cadence.KeyValuePair{{Key: cadence.String("k"), Value: cadence.NewOptional(nil)}})
+	var m map[string]interface{}
+	require.NoError(t, client.Decode(dict, &m))
+	assert.Equal(t, map[string]interface{}{"k": nil}, m)
+}
+
+// TestDecodePointerAndFloat verifies decoding into pointers and of integers into floats.
+func TestDecodePointerAndFloat(t *testing.T) {
+	var s *string
+	require.NoError(t, client.Decode(cadence.String("x"), &s))
+	assert.Equal(t, "x", *s)
+
+	var f float64
+	require.NoError(t, client.Decode(cadence.UInt64(3), &f))
+	assert.Equal(t, 3.0, f)
+}
@@ -0,0 +1,303 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dependencyProject returns a project where the service account's contract
+// imports a contract deployed to a later account.
+func dependencyProject() (map[string]interface{}, map[string]string) {
+	cfg := emulatorProject(map[string]string{"emulator-lib": "01cf0e2f2f715450"})
+	cfg["contracts"] = map[string]string{
+		"App":    "./contract/App.cdc",
+		"Lib":    "./contract/Lib.cdc",
+		"Helper": "./contract/Helper.cdc",
+	}
+	cfg["deployments"] = map[string]interface{}{
+		"emulator": map[string][]string{
+			"emulator-svc": {"App"},
+			"emulator-lib": {"Lib", "Helper"},
+		},
+	}
+
+	files := map[string]string{
+		"contract/App.cdc": `
+import Lib from "./Lib.cdc"
+import Helper from 0xHelper
+
+pub contract App {
+	pub fun answer(): Int { return Lib.answer() + Helper.one() }
+}`,
+		"contract/Lib.cdc": `
+import Helper from "./Helper.cdc"
+
+pub contract Lib {
+	pub fun answer(): Int { return 41 + Helper.zero() }
+}`,
+		"contract/Helper.cdc": `
+pub contract Helper {
+	pub fun zero(): Int { return 0 }
+	pub fun one(): Int { return 1 }
+}`,
+	}
+
+	return cfg, files
+}
+
+// TestDeploymentOrder verifies that contracts are ordered after the contracts they import.
+func TestDeploymentOrder(t *testing.T) {
+	cfg, files := dependencyProject()
+	root := WriteProject(t, cfg, files)
+
+	fj, err := model.FlowJSON{}.FromFile(path.Join(root, "flow.json"))
+	require.NoError(t, err)
+
+	order, err := fj.DeploymentOrder("emulator")
+	require.NoError(t, err)
+
+	var names []string
+	for _, d := range order {
+		names = append(names, d.Contract.Name)
+	}
+	assert.Equal(t, []string{"Helper", "Lib", "App"}, names)
+	assert.Equal(t, "emulator-svc", order[2].Account)
+}
+
+// TestDeploymentOrderErrors verifies that import cycles and missing dependencies are reported.
+func TestDeploymentOrderErrors(t *testing.T) {
+	// Helper imports App, closing a cycle.
+	cfg, files := dependencyProject()
+	files["contract/Helper.cdc"] = "import App from \"./App.cdc\"\n" + files["contract/Helper.cdc"]
+	fj, err := model.FlowJSON{}.FromFile(path.Join(WriteProject(t, cfg, files), "flow.json"))
+	require.NoError(t, err)
+
+	_, err = fj.DeploymentOrder("emulator")
+	var cycleErr *model.ImportCycleError
+	require.True(t, errors.As(err, &cycleErr), "%v", err)
+	assert.Len(t, cycleErr.Cycle, 4)
+	assert.Equal(t, cycleErr.Cycle[0], cycleErr.Cycle[3])
+
+	// Lib imports a contract that is neither deployed nor aliased.
+	cfg, files = dependencyProject()
+	files["contract/Lib.cdc"] = "import Missing from 0xMissing\n" + files["contract/Lib.cdc"]
+	fj, err = model.FlowJSON{}.FromFile(path.Join(WriteProject(t, cfg, files), "flow.json"))
+	require.NoError(t, err)
+
+	_, err = fj.DeploymentOrder("emulator")
+	var missingErr *model.MissingDependencyError
+	require.True(t, errors.As(err, &missingErr), "%v", err)
+	assert.Equal(t, "Lib", missingErr.Contract)
+	assert.Equal(t, "Missing", missingErr.Dependency)
+	assert.Equal(t, 1, missingErr.Line)
+}
+
+// TestDeployDependencyOnLaterAccount verifies that a contract whose dependency lives on a later account deploys.
+func TestDeployDependencyOnLaterAccount(t *testing.T) {
+	cfg, files := dependencyProject()
+	root := WriteProject(t, cfg, files)
+
+	c, err := client.NewGlowClientBuilder(client.NETWORK_EMBEDDED, root, 0).StartE()
+	require.NoError(t, err)
+
+	// App is usable once deployed.
+	res, err := c.NewScFromString(`
+import App from 0xApp
+
+pub fun main(): Int { return App.answer() }`).Exec()
+	require.NoError(t, err)
+	assert.Equal(t, "42", res.String())
+}
+
+// TestRedeploy verifies that re-running Deploy skips unchanged contracts and updates changed ones.
+func TestRedeploy(t *testing.T) {
+	cfg, files := dependencyProject()
+	root := WriteProject(t, cfg, files)
+
+	c, err := client.NewGlowClientBuilder(client.NETWORK_EMBEDDED, root, 0).StartE()
+	require.NoError(t, err)
+
+	// Startup adds every contract.
+	for _, res := range c.DeploymentReport() {
+		assert.Equal(t, client.DEPLOY_ADDED, res.Status, res.Contract)
+	}
+
+	// Nothing changed, so nothing is sent.
+	report, err := c.Deploy()
+	require.NoError(t, err)
+	require.Len(t, report, 3)
+	for _, res := range report {
+		assert.Equal(t, client.DEPLOY_UNCHANGED, res.Status, res.Contract)
+	}
+
+	// Changing Helper updates it in place.
+	helper := files["contract/Helper.cdc"]
+	helper = helper[:len(helper)-1] + "\tpub fun two(): Int { return 2 }\n}"
+	require.NoError(t, os.WriteFile(path.Join(root, "contract", "Helper.cdc"), []byte(helper), 0644))
+
+	report, err = c.Deploy()
+	require.NoError(t, err)
+	assert.Equal(t, client.DEPLOY_UPDATED, report.Status("Helper"))
+	assert.Equal(t, client.DEPLOY_UNCHANGED, report.Status("Lib"))
+
+	res, err := c.NewScFromString(`
+import Helper from 0xHelper
+
+pub fun main(): Int { return Helper.two() }`).Exec()
+	require.NoError(t, err)
+	assert.Equal(t, "2", res.String())
+}
+
+const GREETER_CDC = `
+pub contract Greeter {
+	pub let greeting: String
+	pub let tags: [String]
+
+	init(greeting: String, tags: [String]) {
+		self.greeting = greeting
+		self.tags = tags
+	}
+}`
+
+// TestDeployInitArgs verifies that init arguments from flow.json and from DeployContract reach the initializer.
+func TestDeployInitArgs(t *testing.T) {
+	cfg := emulatorProject(nil)
+	cfg["contracts"] = map[string]string{"Greeter": "./contract/Greeter.cdc"}
+	cfg["deployments"] = map[string]interface{}{
+		"emulator": map[string]interface{}{
+			"emulator-svc": []interface{}{
+				map[string]interface{}{
+					"name": "Greeter",
+					"args": []interface{}{
+						map[string]interface{}{"type": "String", "value": "hello"},
+						map[string]interface{}{"type": "Array", "value": []interface{}{
+							map[string]interface{}{"type": "String", "value": "a"},
+						}},
+					},
+				},
+			},
+		},
+	}
+	root := WriteProject(t, cfg, map[string]string{"contract/Greeter.cdc": GREETER_CDC})
+
+	c, err := client.NewGlowClientBuilder(client.NETWORK_EMBEDDED, root, 0).StartE()
+	require.NoError(t, err)
+
+	res, err := c.NewScFromString(`
+import Greeter from 0xGreeter
+
+pub fun main(): String { return Greeter.greeting.concat(Greeter.tags[0]) }`).Exec()
+	require.NoError(t, err)
+	assert.Equal(t, `"helloa"`, res.String())
+
+	// Deploy a second copy to a new account through the Go API.
+	acct, err := c.CreateDisposableAccount()
+	require.NoError(t, err)
+
+	_, err = c.DeployContract(
+		*acct,
+		"Greeter",
+		cadence.String("hi"),
+		cadence.NewArray([]cadence.Value{cadence.String("b")}),
+	)
+	require.NoError(t, err)
+
+	res, err = c.NewScFromString(
+		fmt.Sprintf(`
+import Greeter from %s
+
+pub fun main(): String { return Greeter.greeting.concat(Greeter.tags[0]) }`, acct.Address),
+	).Exec()
+	require.NoError(t, err)
+	assert.Equal(t, `"hib"`, res.String())
+}
+
+const SHELF_CDC = `
+import MetadataViews from 0xMetadataViews
+
+pub contract Shelf {
+	pub let edition: MetadataViews.Edition
+	pub let ids: [UInt64]
+
+	init(edition: MetadataViews.Edition, ids: [UInt64]) {
+		self.edition = edition
+		self.ids = ids
+	}
+}`
+
+// TestDeployInitArgTypes verifies init arguments whose types cannot be
+// written from their values: composites and empty arrays.
+func TestDeployInitArgTypes(t *testing.T) {
+	cfg := emulatorProject(nil)
+	cfg["contracts"] = map[string]interface{}{
+		"Shelf": "./contract/Shelf.cdc",
+		"MetadataViews": map[string]interface{}{
+			"source":  "./contract/MetadataViews.cdc",
+			"aliases": map[string]string{"emulator": "f8d6e0586b0a20c7"},
+		},
+	}
+	edition := map[string]interface{}{"type": "Struct", "value": map[string]interface{}{
+		"id": "A.f8d6e0586b0a20c7.MetadataViews.Edition",
+		"fields": []interface{}{
+			map[string]interface{}{"name": "name", "value": map[string]interface{}{"type": "Optional", "value": nil}},
+			map[string]interface{}{"name": "number", "value": map[string]interface{}{"type": "UInt64", "value": "3"}},
+			map[string]interface{}{"name": "max", "value": map[string]interface{}{"type": "Optional", "value": nil}},
+		},
+	}}
+	cfg["deployments"] = map[string]interface{}{
+		"emulator": map[string]interface{}{
+			"emulator-svc": []interface{}{
+				map[string]interface{}{
+					"name": "Shelf",
+					"args": []interface{}{edition, map[string]interface{}{"type": "Array", "value": []interface{}{}}},
+				},
+			},
+		},
+	}
+	root := WriteProject(t, cfg, map[string]string{"contract/Shelf.cdc": SHELF_CDC})
+
+	c, err := client.NewGlowClientBuilder(client.NETWORK_EMBEDDED, root, 0).StartE()
+	require.NoError(t, err)
+
+	sc := `
+import Shelf from %s
+
+pub fun main(): String { return Shelf.edition.number.toString().concat("/").concat(Shelf.ids.length.toString()) }`
+	res, err := c.NewScFromString(fmt.Sprintf(sc, "0xShelf")).Exec()
+	require.NoError(t, err)
+	assert.Equal(t, `"3/0"`, res.String())
+
+	// the same through the Go API
+	acct, err := c.CreateDisposableAccount()
+	require.NoError(t, err)
+	arg := []cadence.Value{
+		cadence.NewStruct([]cadence.Value{
+			cadence.NewOptional(nil), cadence.UInt64(5), cadence.NewOptional(nil),
+		}).WithType(&cadence.StructType{
+			Location:            common.NewAddressLocation(nil, common.MustBytesToAddress(flow.HexToAddress("f8d6e0586b0a20c7").Bytes()), "MetadataViews"),
+			QualifiedIdentifier: "MetadataViews.Edition",
+			Fields:              []cadence.Field{{Identifier: "name"}, {Identifier: "number"}, {Identifier: "max"}},
+		}),
+		cadence.NewArray(nil),
+	}
+	_, err = c.DeployContract(*acct, "Shelf", arg...)
+	require.NoError(t, err)
+
+	res, err = c.NewScFromString(fmt.Sprintf(sc, acct.Address)).Exec()
+	require.NoError(t, err)
+	assert.Equal(t, `"5/0"`, res.String())
+
+	_, err = c.DeployContract(*acct, "Shelf", arg[0])
+	assert.ErrorContains(t, err, "init takes 2 arguments, got 1")
+}
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// mintAndTransfer mints an NFT to the service account and transfers it to
+// the test account, returning the transfer result.
+func mintAndTransfer(g *glowtest.T) *client.TxResult {
+	minter := g.Client.SvcAcct
+	collector := g.Account("test")
+
+	g.Send(g.Tx("account_setup_royalty", minter, cadence.Path{
+		Domain:     common.PathDomainStorage,
+		Identifier: "flowTokenVault",
+	}))
+	g.Send(g.Tx("nft_mint", minter,
+		minter.CadenceAddress(),
+		cadence.String("name"),
+		cadence.String("description"),
+		cadence.String("thumbnail"),
+		cadence.NewArray([]cadence.Value{cadence.UFix64(100)}),
+		cadence.NewArray([]cadence.Value{cadence.String("royalty description")}),
+		cadence.NewArray([]cadence.Value{minter.CadenceAddress()}),
+	))
+	g.Send(g.Tx("account_setup", collector))
+
+	return g.Send(g.Tx("nft_transfer", minter, collector.CadenceAddress(), cadence.UInt64(0)))
+}
+
+// TestEventAssertions verifies the event assertion helpers on an NFT transfer.
+func TestEventAssertions(t *testing.T) {
+	g := glowtest.New(t)
+	res := mintAndTransfer(g)
+	collector := g.Account("test")
+
+	glowtest.AssertEventEmitted(t, res, "ExampleNFT.Deposit", map[string]any{"id": 0})
+	glowtest.AssertEventEmitted(t, res, "Deposit", map[string]any{
+		"id": cadence.UInt64(0),
+		"to": collector.CadenceAddress(),
+	})
+	glowtest.AssertEventEmitted(t, res, "ExampleNFT.Withdraw", map[string]any{"from": g.Client.SvcAcct.FlowAddress()})
+	glowtest.AssertEventCount(t, res, "ExampleNFT.Deposit", 1)
+	glowtest.AssertNoEvent(t, res, "ExampleNFT.ContractInitialized")
+	glowtest.AssertEventsInOrder(t, res, []glowtest.ExpectedEvent{
+		{Type: "ExampleNFT.Withdraw", Fields: map[string]any{"id": 0}},
+		{Type: "ExampleNFT.Deposit", Fields: map[string]any{"id": 0}},
+	})
+}
+
+// TestEventAssertionFailures verifies that failed event assertions report the actual fields.
+func TestEventAssertionFailures(t *testing.T) {
+	g := glowtest.New(t)
+	res := mintAndTransfer(g)
+
+	rec := &recordingT{}
+	assert.False(t, glowtest.AssertEventEmitted(rec, res, "ExampleNFT.Deposit", map[string]any{"id": 1}))
+	assert.Contains(t, rec.msg, "id: expected 1, actual 0")
+
+	rec = &recordingT{}
+	assert.False(t, glowtest.AssertEventCount(rec, res, "ExampleNFT.Deposit", 2))
+	assert.Contains(t, rec.msg, "expected 2 ExampleNFT.Deposit event(s), got 1")
+
+	rec = &recordingT{}
+	assert.False(t, glowtest.AssertNoEvent(rec, res, "ExampleNFT.Withdraw"))
+	assert.Contains(t, rec.msg, "ExampleNFT.Withdraw(id: 0")
+
+	rec = &recordingT{}
+	assert.False(t, glowtest.AssertEventsInOrder(rec, res, []glowtest.ExpectedEvent{
+		{Type: "ExampleNFT.Deposit"},
+		{Type: "ExampleNFT.Withdraw"},
+	}))
+	assert.Contains(t, rec.msg, "matched 1 of 2 expected events")
+}
+
+// recordingT records assertion failures instead of failing the test.
+type recordingT struct {
+	msg string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.msg += fmt.Sprintf(format, args...)
+}
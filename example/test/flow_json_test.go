@@ -0,0 +1,222 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/rrossilli/glow/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ADVANCED_FLOW_JSON = `{
+  "emulators": {
+    "default": {"port": 3569, "serviceAccount": "emulator-svc"}
+  },
+  "contracts": {
+    "Simple": "./contract/Simple.cdc",
+    "Advanced": {
+      "source": "./contract/Advanced.cdc",
+      "aliases": {"testnet": "0x9a0766d93b6608b7"}
+    }
+  },
+  "networks": {
+    "emulator": "127.0.0.1:3569",
+    "testnet-secure": {
+      "host": "access-001.devnet30.nodes.onflow.org:9001",
+      "key": "ba69f7d2e82b9edf25b103c195cd371cf0cc047ef8884a9bbe331e62982d46daeebf836f7445a2ac16741013b192959d8ad26998aff12f2adc67a99e1eb2988d"
+    }
+  },
+  "accounts": {
+    "emulator-svc": {
+      "address": "f8d6e0586b0a20c7",
+      "key": "3a63ae4f8fffacd89d1b574d87fe448a0f848da7d0a45c04b60744b1c3905a14"
+    },
+    "testnet-advanced": {
+      "address": "0x01cf0e2f2f715450",
+      "key": {
+        "type": "hex",
+        "index": 1,
+        "signatureAlgorithm": "ECDSA_secp256k1",
+        "hashAlgorithm": "SHA2_256",
+        "privateKey": "4a4f7a1d07b441135489823f1bcdc27ba607c1916b3b182a2b7ee91cf11eb5f6"
+      }
+    },
+    "testnet-kms": {
+      "address": "0x179b6b1cb6755e31",
+      "key": {
+        "type": "google-kms",
+        "resourceID": "projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+      }
+    }
+  },
+  "deployments": {
+    "emulator": {
+      "emulator-svc": [
+        "Simple",
+        {
+          "name": "Advanced",
+          "args": [{"type": "String", "value": "hello"}, {"type": "UInt64", "value": "42"}]
+        }
+      ]
+    }
+  }
+}`
+
+// TestFlowJSONExampleProject verifies the example project's flow.json is fully parsed.
+func TestFlowJSONExampleProject(t *testing.T) {
+	b, err := os.ReadFile(path.Join(os.Getenv("GLOW_ROOT"), "flow.json"))
+	require.NoError(t, err)
+
+	fj, err := model.FlowJSON{}.FromBytes(b)
+	require.NoError(t, err)
+
+	// Emulators, contracts and networks.
+	assert.Equal(t, model.Emulator{Port: 3569, ServiceAccount: "emulator-svc"}, fj.Emulator("default"))
+	assert.Len(t, fj.Contracts(), 7)
+	assert.Equal(t, "./contract/ExampleNFT.cdc", fj.Contract("ExampleNFT").Source)
+	assert.Equal(t, "0x9a0766d93b6608b7", fj.Contract("FungibleToken").Address("testnet"))
+	assert.Equal(t, "127.0.0.1:3569", fj.Network("emulator").Host)
+
+	// Accounts and deployments.
+	svc := fj.ServiceAccount("emulator")
+	assert.Equal(t, "f8d6e0586b0a20c7", svc.Address)
+	assert.Equal(t, "3a63ae4f8fffacd89d1b574d87fe448a0f848da7d0a45c04b60744b1c3905a14", svc.PrivKey)
+	assert.Len(t, fj.Accounts("emulator"), 2)
+	assert.Equal(t, []string{"NFTStorefront", "ExampleNFT"}, fj.Deployment("emulator").ContractNames("emulator-svc"))
+}
+
+// TestFlowJSONAdvancedFormats verifies the advanced contract, network, account and deployment formats.
+func TestFlowJSONAdvancedFormats(t *testing.T) {
+	fj, err := model.FlowJSON{}.FromBytes([]byte(ADVANCED_FLOW_JSON))
+	require.NoError(t, err)
+
+	// Simple and advanced contracts.
+	assert.Equal(t, model.Contract{Source: "./contract/Simple.cdc"}, fj.Contract("Simple"))
+	assert.Equal(t, "0x9a0766d93b6608b7", fj.Contract("Advanced").Address("testnet"))
+
+	// Advanced network with a pinned key.
+	secure := fj.Network("testnet-secure")
+	assert.Equal(t, "access-001.devnet30.nodes.onflow.org:9001", secure.Host)
+	assert.NotEmpty(t, secure.Key)
+
+	// Advanced hex account key.
+	advanced := fj.Account("testnet-advanced")
+	assert.Equal(t, "4a4f7a1d07b441135489823f1bcdc27ba607c1916b3b182a2b7ee91cf11eb5f6", advanced.PrivKey)
+	assert.Equal(t, model.KEY_TYPE_HEX, advanced.Key.Type)
+	assert.Equal(t, 1, advanced.Key.Index)
+	assert.Equal(t, "ECDSA_secp256k1", advanced.Key.SigAlgo)
+	assert.Equal(t, "SHA2_256", advanced.Key.HashAlgo)
+
+	// Non-hex account keys carry no private key.
+	kms := fj.Account("testnet-kms")
+	assert.Equal(t, model.KEY_TYPE_GOOGLE_KMS, kms.Key.Type)
+	assert.Empty(t, kms.PrivKey)
+
+	// Deployments with init args.
+	contracts := fj.Deployment("emulator").Contracts("emulator-svc")
+	require.Len(t, contracts, 2)
+	assert.Equal(t, "Simple", contracts[0].Name)
+	assert.Empty(t, contracts[0].Args)
+	assert.Equal(t, "Advanced", contracts[1].Name)
+	require.Len(t, contracts[1].Args, 2)
+	assert.JSONEq(t, `{"type": "UInt64", "value": "42"}`, string(contracts[1].Args[1]))
+}
+
+// TestFlowJSONRoundTrip verifies that marshalling and re-parsing flow.json is lossless.
+func TestFlowJSONRoundTrip(t *testing.T) {
+	example, err := os.ReadFile(path.Join(os.Getenv("GLOW_ROOT"), "flow.json"))
+	require.NoError(t, err)
+
+	for name, src := range map[string][]byte{
+		"example":  example,
+		"advanced": []byte(ADVANCED_FLOW_JSON),
+	} {
+		t.Run(name, func(t *testing.T) {
+			fj, err := model.FlowJSON{}.FromBytes(src)
+			require.NoError(t, err)
+
+			out, err := json.Marshal(fj)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(src), string(out))
+
+			again, err := model.FlowJSON{}.FromBytes(out)
+			require.NoError(t, err)
+			assert.Equal(t, fj.Accounts(""), again.Accounts(""))
+			assert.Equal(t, fj.Contracts(), again.Contracts())
+
+			outAgain, err := json.Marshal(again)
+			require.NoError(t, err)
+			assert.Equal(t, string(out), string(outAgain))
+		})
+	}
+}
+
+const INVALID_FLOW_JSON = `{
+  "contracts": {
+    "Token": "./contract/Token.cdc",
+    "Missing": "./contract/Missing.cdc",
+    "Dependency": "./contract/Dependency.cdc"
+  },
+  "accounts": {
+    "emulator-svc": {
+      "address": "f8d6e0586b0a20c7",
+      "key": "not-a-key"
+    },
+    "emulator-testnet": {
+      "address": "0x9a0766d93b6608b7",
+      "key": "4a4f7a1d07b441135489823f1bcdc27ba607c1916b3b182a2b7ee91cf11eb5f6"
+    }
+  },
+  "deployments": {
+    "emulator": {
+      "emulator-svc": ["Token", "Missing", "Undefined"],
+      "emulator-ghost": ["Token"]
+    }
+  }
+}`
+
+// TestFlowJSONValidate verifies that configuration problems are reported with their flow.json position.
+func TestFlowJSONValidate(t *testing.T) {
+	// The example project is valid.
+	fj, err := model.FlowJSON{}.FromFile(path.Join(os.Getenv("GLOW_ROOT"), "flow.json"))
+	require.NoError(t, err)
+	assert.False(t, model.HasErrors(fj.Validate("emulator")), "%v", fj.Validate("emulator"))
+
+	// Write a broken project to a temporary root.
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(path.Join(root, "contract"), 0755))
+	require.NoError(t, os.WriteFile(path.Join(root, "contract", "Token.cdc"), []byte(
+		"import Dependency from \"./Dependency.cdc\"\nimport Other from 0x01cf0e2f2f715450\n\npub contract Token {}\n",
+	), 0644))
+	require.NoError(t, os.WriteFile(path.Join(root, "flow.json"), []byte(INVALID_FLOW_JSON), 0644))
+
+	fj, err = model.FlowJSON{}.FromFile(path.Join(root, "flow.json"))
+	require.NoError(t, err)
+	diags := fj.Validate("emulator")
+	require.True(t, model.HasErrors(diags))
+
+	byPath := map[string]model.Diagnostic{}
+	for _, d := range diags {
+		byPath[d.Path] = d
+	}
+
+	// Dependency is neither deployed nor aliased, and Missing has no source on disk.
+	assert.Equal(t, 3, byPath["contracts.Token"].Line)
+	assert.Contains(t, byPath["contracts.Token"].Message, "Dependency")
+	assert.Equal(t, 4, byPath["contracts.Missing"].Line)
+	assert.Contains(t, byPath["contracts.Missing"].Message, "not found")
+
+	// The service key does not decode and the testnet address is not valid on the emulator.
+	assert.Equal(t, 10, byPath["accounts.emulator-svc.key"].Line)
+	assert.Equal(t, 7, byPath["accounts.emulator-svc.key"].Column)
+	assert.Contains(t, byPath["accounts.emulator-testnet.address"].Message, "not valid on chain")
+
+	// Deployments reference an undefined contract and an undefined account.
+	undefined := byPath["deployments.emulator.emulator-svc[2]"]
+	assert.Equal(t, 19, undefined.Line)
+	assert.Contains(t, undefined.Message, "Undefined")
+	assert.Equal(t, 20, byPath["deployments.emulator.emulator-ghost"].Line)
+}
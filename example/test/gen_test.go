@@ -0,0 +1,85 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/rrossilli/glow/example/bindings"
+	"github.com/rrossilli/glow/gen"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenBindings verifies minting and borrowing an NFT through the generated bindings.
+func TestGenBindings(t *testing.T) {
+	g := glowtest.New(t)
+	minter := g.Client.SvcAcct
+
+	vault := cadence.Path{Domain: common.PathDomainStorage, Identifier: "flowTokenVault"}
+	_, err := bindings.AccountSetupRoyalty(g.Client, minter, vault).SignAndSend()
+	require.NoError(t, err)
+
+	collector := g.Account("test")
+	_, err = bindings.AccountSetup(g.Client, collector).SignAndSend()
+	require.NoError(t, err)
+
+	_, err = bindings.NFTMint(g.Client, minter,
+		collector.FlowAddress(),
+		"name", "description", "thumbnail",
+		[]cadence.UFix64{100},
+		[]string{"royalty description"},
+		[]flow.Address{minter.FlowAddress()},
+	).SignAndSend()
+	require.NoError(t, err)
+
+	require.NoError(t, bindings.NFTBorrow(g.Client, collector.FlowAddress(), 0))
+	assert.Error(t, bindings.NFTBorrow(g.Client, collector.FlowAddress(), 1))
+
+	balance, err := bindings.FlowBalance(g.Client, collector.FlowAddress())
+	require.NoError(t, err)
+	assert.Greater(t, uint64(balance), uint64(0))
+}
+
+// TestGenUpToDate verifies that the example bindings match the example's Cadence files.
+func TestGenUpToDate(t *testing.T) {
+	root := os.Getenv("GLOW_ROOT")
+	cfg := gen.Config{Root: root, Package: "bindings"}
+	assert.NoError(t, gen.Check(cfg, filepath.Join(root, "bindings", "glow_gen.go")))
+}
+
+// TestGenTypes verifies the Go types and signers of generated functions,
+// and that changing a Cadence signature makes the bindings stale.
+func TestGenTypes(t *testing.T) {
+	root := WriteProject(t, emulatorProject(nil), map[string]string{
+		"transaction/swap.cdc": `
+			import FungibleToken from 0xFungibleToken
+			transaction(amounts: {String: UFix64}, memo: String?, type: Int) {
+				prepare(buyer: AuthAccount, seller: AuthAccount) {}
+			}`,
+		"transaction/ping.cdc": `transaction { execute {} }`,
+		"script/get_ids.cdc":   `pub fun main(owner: Address): [UInt64]? { return nil }`,
+	})
+	cfg := gen.Config{Root: root, Package: "bindings"}
+
+	src, err := gen.Generate(cfg)
+	require.NoError(t, err)
+	code := string(src)
+	assert.Contains(t, code, "func Swap(c *client.GlowClient, buyer model.Account, seller model.Account, amounts map[string]cadence.UFix64, memo *string, type_ int) *client.Tx")
+	assert.Contains(t, code, ".Authorizers(buyer, seller)")
+	assert.Contains(t, code, "func Ping(c *client.GlowClient, proposer model.Account) *client.Tx")
+	assert.Contains(t, code, ".Authorizers()")
+	assert.Contains(t, code, "func GetIDs(c *client.GlowClient, owner flow.Address) (result *[]uint64, err error)")
+
+	out := filepath.Join(t.TempDir(), "glow_gen.go")
+	require.NoError(t, os.WriteFile(out, src, 0644))
+	require.NoError(t, gen.Check(cfg, out))
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "script", "get_ids.cdc"),
+		[]byte(`pub fun main(owner: Address, limit: Int): [UInt64]? { return nil }`), 0644))
+	assert.ErrorIs(t, gen.Check(cfg, out), gen.ErrStale)
+}
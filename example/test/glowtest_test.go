@@ -0,0 +1,65 @@
+package test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGlowtestIsolation verifies that state changed by one test is rolled back before the next.
+func TestGlowtestIsolation(t *testing.T) {
+	var before uint64
+	balance := func(g *glowtest.T) uint64 {
+		return g.Exec(g.Sc("flow_balance", g.Account("test").CadenceAddress())).ToGoValue().(uint64)
+	}
+
+	t.Run("transfer", func(t *testing.T) {
+		g := glowtest.New(t)
+		before = balance(g)
+
+		amount, _ := cadence.NewUFix64("10.0")
+		g.Send(g.Tx("flow_transfer", g.Client.SvcAcct, amount, g.Account("test").CadenceAddress()))
+		assert.Greater(t, balance(g), before)
+	})
+
+	t.Run("rolled back", func(t *testing.T) {
+		g := glowtest.New(t)
+		assert.Equal(t, before, balance(g))
+	})
+}
+
+// TestGlowtestFailure verifies that failing transactions fail the test with the Cadence error.
+func TestGlowtestFailure(t *testing.T) {
+	ft := &fakeT{TB: t}
+	g := glowtest.New(t)
+	g.TB = ft
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.Send(g.Client.NewTxFromString(`transaction { execute { panic("boom") } }`, g.Client.SvcAcct))
+	}()
+	<-done
+
+	assert.True(t, ft.failed)
+	assert.Contains(t, ft.msg, "boom")
+}
+
+// fakeT records a fatal failure instead of ending the test.
+type fakeT struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Fatalf(format string, args ...interface{}) {
+	f.failed = true
+	f.msg = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
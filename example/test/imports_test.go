@@ -0,0 +1,112 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/rrossilli/glow/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const SC_IMPORTS = `
+// import NonFungibleToken from 0xNonFungibleToken
+import FungibleToken, FlowToken from "../contracts/FungibleToken.cdc"
+import MetadataViews from 0xMetadataViews /* from 0xMetadataViews */
+import "ExampleNFT"
+import Crypto
+
+pub fun main(): [String] {
+	return [
+		"import NonFungibleToken from 0xNonFungibleToken",
+		Type<FungibleToken>().identifier,
+		Type<FlowToken>().identifier,
+		Type<MetadataViews.Edition>().identifier,
+		Type<ExampleNFT>().identifier
+	]
+}`
+
+// TestParseImports verifies the kinds and positions of parsed import declarations.
+func TestParseImports(t *testing.T) {
+	imports := model.ParseImports(SC_IMPORTS)
+	require.Len(t, imports, 3)
+
+	assert.Equal(t, model.IMPORT_FILE, imports[0].Kind)
+	assert.Equal(t, []string{"FungibleToken", "FlowToken"}, imports[0].Names)
+	assert.Equal(t, "../contracts/FungibleToken.cdc", imports[0].Location)
+	assert.Equal(t, 3, imports[0].Line)
+
+	assert.Equal(t, model.IMPORT_PLACEHOLDER, imports[1].Kind)
+	assert.Equal(t, "0xMetadataViews", imports[1].Location)
+	assert.Equal(t, "import MetadataViews from 0xMetadataViews", SC_IMPORTS[imports[1].Start:imports[1].End])
+
+	assert.Equal(t, model.IMPORT_STRING, imports[2].Kind)
+	assert.Equal(t, []string{"ExampleNFT"}, imports[2].Names)
+
+	addr := model.ParseImports(`import FlowToken from 0x0ae53cb6e3f42a79`)
+	require.Len(t, addr, 1)
+	assert.Equal(t, model.IMPORT_ADDRESS, addr[0].Kind)
+	assert.Nil(t, addr[0].ContractNames())
+
+	// contract names made only of hex letters are placeholders
+	code := `import Cafe from 0xCafe
+import A from 0xA`
+	hex := model.ParseImports(code, "Cafe", "A")
+	require.Len(t, hex, 2)
+	assert.Equal(t, model.IMPORT_PLACEHOLDER, hex[0].Kind)
+	assert.Equal(t, "0xCafe", hex[0].Location)
+	assert.Equal(t, model.IMPORT_PLACEHOLDER, hex[1].Kind)
+	assert.Equal(t, model.IMPORT_ADDRESS, model.ParseImports(code, "Other")[0].Kind)
+}
+
+// TestImportRewriting verifies that only import declarations are rewritten.
+func TestImportRewriting(t *testing.T) {
+	g := glowtest.New(t)
+
+	res, err := g.Client.NewScFromString(SC_IMPORTS).Exec()
+	require.NoError(t, err)
+
+	var got []string
+	for _, v := range res.(cadence.Array).Values {
+		got = append(got, string(v.(cadence.String)))
+	}
+	assert.Equal(t, []string{
+		"import NonFungibleToken from 0xNonFungibleToken",
+		"A.ee82856bf20e2aa6.FungibleToken",
+		"A.0ae53cb6e3f42a79.FlowToken",
+		"A.f8d6e0586b0a20c7.MetadataViews.Edition",
+		"A.f8d6e0586b0a20c7.ExampleNFT",
+	}, got)
+}
+
+// TestUnresolvedImports verifies that code importing unknown contracts is not sent.
+func TestUnresolvedImports(t *testing.T) {
+	g := glowtest.New(t)
+	code := `
+import "NonFungibleToken"
+import "Missing"
+import Other from 0xOther
+
+pub fun main() {}`
+
+	_, err := g.Client.NewScFromString(code).Exec()
+	var importErr *client.UnresolvedImportsError
+	require.ErrorAs(t, err, &importErr)
+	assert.Equal(t, "emulator", importErr.Network)
+	require.Len(t, importErr.Imports, 2)
+	assert.ErrorContains(t, err, "line 3: import \"Missing\"")
+	assert.ErrorContains(t, err, "line 4: import Other from 0xOther")
+
+	svc := g.Client.SvcAcct
+	_, err = g.Client.NewTxFromString(`import "Missing"
+transaction {}`, svc).SignAndSend()
+	require.ErrorAs(t, err, &importErr)
+	assert.Equal(t, "Missing", importErr.Imports[0].Names[0])
+
+	_, err = g.Client.NewTx([]byte(`import Other from 0xOther
+transaction {}`), svc).SignAndSend()
+	require.ErrorAs(t, err, &importErr)
+	assert.Equal(t, "Other", importErr.Imports[0].Names[0])
+}
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/rrossilli/glow/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAccountKeyAlgorithms verifies that a flow.json account with a
+// secp256k1 key and SHA2_256 hashing is created and signs with them.
+func TestAccountKeyAlgorithms(t *testing.T) {
+	key := newKey(t, crypto.ECDSA_secp256k1, crypto.SHA2_256, 1000)
+
+	cfg := emulatorProject(nil)
+	cfg["accounts"].(map[string]interface{})["emulator-secp"] = map[string]interface{}{
+		"address": "01cf0e2f2f715450",
+		"key": map[string]string{
+			"type":               "hex",
+			"privateKey":         key.PrivateKey,
+			"signatureAlgorithm": key.SigAlgo,
+			"hashAlgorithm":      key.HashAlgo,
+		},
+	}
+	g := glowtest.NewWithRoot(t, WriteProject(t, cfg, nil))
+	acct := g.Account("secp")
+
+	onChain, err := g.Client.FlowKit.GetAccount(context.Background(), acct.FlowAddress())
+	require.NoError(t, err)
+	require.Len(t, onChain.Keys, 1)
+	assert.Equal(t, crypto.ECDSA_secp256k1, onChain.Keys[0].SigAlgo)
+	assert.Equal(t, crypto.SHA2_256, onChain.Keys[0].HashAlgo)
+
+	// the account proposes, pays and authorizes with its own key
+	_, err = g.Client.NewTxFromString(`transaction { prepare(signer: AuthAccount) {} }`, acct).SignAndSend()
+	require.NoError(t, err)
+
+	// messages are signed with the key's hash algorithm
+	msg := []byte("some_data")
+	sig, err := acct.SignMessage(msg)
+	require.NoError(t, err)
+	hasher, err := crypto.NewHasher(crypto.SHA2_256)
+	require.NoError(t, err)
+	tagged := append(flow.UserDomainTag[:], msg...)
+	valid, err := acct.CryptoPublicKey().Verify(sig, tagged, hasher)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+// TestCreateAccountHashAlgorithms verifies that one client creates accounts
+// whose keys use different algorithms.
+func TestCreateAccountHashAlgorithms(t *testing.T) {
+	g := glowtest.New(t)
+
+	for _, c := range []struct {
+		sigAlgo  crypto.SignatureAlgorithm
+		hashAlgo crypto.HashAlgorithm
+	}{
+		{crypto.ECDSA_P256, crypto.SHA3_256},
+		{crypto.ECDSA_secp256k1, crypto.SHA2_256},
+		{crypto.ECDSA_P256, crypto.SHA2_256},
+	} {
+		key := newKey(t, c.sigAlgo, c.hashAlgo, 1000)
+		pk, err := crypto.DecodePrivateKeyHex(c.sigAlgo, util.RemoveHexPrefix(key.PrivateKey))
+		require.NoError(t, err)
+
+		acct, err := g.Client.CreateAccount(pk, c.hashAlgo)
+		require.NoError(t, err)
+		assert.Equal(t, c.hashAlgo, acct.Key.HashAlgorithm())
+
+		onChain, err := g.Client.FlowKit.GetAccount(context.Background(), acct.FlowAddress())
+		require.NoError(t, err)
+		require.Len(t, onChain.Keys, 1)
+		assert.Equal(t, c.sigAlgo, onChain.Keys[0].SigAlgo)
+		assert.Equal(t, c.hashAlgo, onChain.Keys[0].HashAlgo)
+
+		_, err = g.Client.NewTxFromString(`transaction { prepare(signer: AuthAccount) {} }`, *acct).SignAndSend()
+		require.NoError(t, err, c.hashAlgo)
+	}
+}
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/rrossilli/glow/model"
+	"github.com/rrossilli/glow/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newKey returns an account key with a random private key.
+func newKey(t *testing.T, sigAlgo crypto.SignatureAlgorithm, hashAlgo crypto.HashAlgorithm, weight int) model.AccountKey {
+	seed := make([]byte, crypto.MinSeedLength)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	pk, err := crypto.GeneratePrivateKey(sigAlgo, seed)
+	require.NoError(t, err)
+
+	return model.AccountKey{
+		PrivateKey: pk.String(),
+		SigAlgo:    sigAlgo.String(),
+		HashAlgo:   hashAlgo.String(),
+		Weight:     weight,
+	}
+}
+
+// TestMultiSig verifies account creation with a key set and weighted signing.
+func TestMultiSig(t *testing.T) {
+	g := glowtest.New(t)
+	svc := g.Client.SvcAcct
+
+	acct, err := g.Client.CreateAccountWithKeys(
+		newKey(t, crypto.ECDSA_P256, crypto.SHA3_256, 500),
+		newKey(t, crypto.ECDSA_secp256k1, crypto.SHA2_256, 500),
+		newKey(t, crypto.ECDSA_P256, crypto.SHA3_256, 400),
+	)
+	require.NoError(t, err)
+	assert.Len(t, acct.Keys, 3)
+
+	onChain, err := g.Client.FlowKit.GetAccount(context.Background(), acct.FlowAddress())
+	require.NoError(t, err)
+	require.Len(t, onChain.Keys, 3)
+	assert.Equal(t, 500, onChain.Keys[1].Weight)
+	assert.Equal(t, crypto.ECDSA_secp256k1, onChain.Keys[1].SigAlgo)
+	assert.Equal(t, crypto.SHA2_256, onChain.Keys[1].HashAlgo)
+	assert.Equal(t, 400, onChain.Keys[2].Weight)
+
+	tx := func(a model.Account) error {
+		_, err := g.Client.NewTxFromString(`transaction { prepare(signer: AuthAccount) {} }`, a).
+			Payer(svc).
+			SignAndSend()
+		return err
+	}
+
+	// keys 0 and 1 reach the threshold of 1000
+	require.NoError(t, tx(acct.WithSigningKeys(0, 1)))
+
+	// keys 0 and 2 only sum to 900
+	assert.ErrorContains(t, tx(acct.WithSigningKeys(0, 2)), "sufficient")
+
+	// the proposal key signs along with the signing keys
+	require.NoError(t, tx(acct.WithKeyIndex(2).WithSigningKeys(0, 1)))
+
+	_, err = g.Client.CreateAccountWithKeys()
+	assert.Error(t, err)
+}
+
+// TestMultiSigPublicKey verifies a custody account holding another party's public key.
+func TestMultiSigPublicKey(t *testing.T) {
+	g := glowtest.New(t)
+
+	partner := newKey(t, crypto.ECDSA_P256, crypto.SHA3_256, 500)
+	pk, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, util.RemoveHexPrefix(partner.PrivateKey))
+	require.NoError(t, err)
+	partner.PublicKey = pk.PublicKey().String()
+	partner.PrivateKey = ""
+
+	acct, err := g.Client.CreateAccountWithKeys(
+		partner,
+		newKey(t, crypto.ECDSA_P256, crypto.SHA3_256, 500),
+		newKey(t, crypto.ECDSA_secp256k1, crypto.SHA2_256, 500),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, acct.Key.Index, "the first key that can sign proposes")
+
+	onChain, err := g.Client.FlowKit.GetAccount(context.Background(), acct.FlowAddress())
+	require.NoError(t, err)
+	require.Len(t, onChain.Keys, 3)
+	assert.True(t, pk.PublicKey().Equals(onChain.Keys[0].PublicKey))
+
+	tx := func(a model.Account) error {
+		_, err := g.Client.NewTxFromString(`transaction { prepare(signer: AuthAccount) {} }`, a).
+			Payer(g.Client.SvcAcct).
+			SignAndSend()
+		return err
+	}
+
+	// our two keys reach the threshold without the partner's
+	require.NoError(t, tx(acct.WithSigningKeys(1, 2)))
+	assert.Error(t, tx(acct.WithSigningKeys(0, 1)))
+}
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/rrossilli/glow/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOfflineSigning verifies co-signing a transaction with a partner who
+// signs an exported copy and returns it, in both export formats.
+func TestOfflineSigning(t *testing.T) {
+	g := glowtest.New(t)
+	svc := g.Client.SvcAcct
+	user := g.Account("test")
+
+	for _, format := range []string{client.TX_FORMAT_RLP, client.TX_FORMAT_JSON} {
+		t.Run(format, func(t *testing.T) {
+			privKey, err := g.Client.NewPrivateKey(GENERATE_KEYS_SEED_PHRASE)
+			require.NoError(t, err)
+			partner, err := g.Client.CreateAccount(privKey)
+			require.NoError(t, err)
+
+			// the partner's keys are not available locally
+			partnerAddr := model.NewUnqualifiedAccount(partner.Address)
+			expected := cadence.NewArray([]cadence.Value{user.CadenceAddress(), partnerAddr.CadenceAddress()})
+			tx, err := g.Client.NewTxFromString(TX_AUTHORIZERS, user, expected).
+				Payer(svc).
+				Authorizers(user, partnerAddr).
+				Build()
+			require.NoError(t, err)
+			require.NoError(t, tx.SignWith(user))
+
+			dir := t.TempDir()
+			unsigned := filepath.Join(dir, "tx."+format)
+			require.NoError(t, tx.ExportFile(unsigned, format))
+
+			// the partner imports, signs and exports
+			theirs, err := g.Client.ImportTxFile(unsigned)
+			require.NoError(t, err)
+			require.NoError(t, theirs.SignWith(*partner))
+			signed := filepath.Join(dir, "signed."+format)
+			require.NoError(t, theirs.ExportFile(signed, format))
+
+			require.NoError(t, tx.AddSignatureFile(signed))
+
+			// the payer has not signed yet
+			_, err = tx.Send()
+			var missing *client.MissingSignaturesError
+			require.ErrorAs(t, err, &missing)
+			assert.Len(t, missing.Missing, 1)
+			assert.Contains(t, missing.Missing[0], "envelope signatures of "+svc.CadenceAddress().String())
+
+			require.NoError(t, tx.SignWith(svc))
+			_, err = tx.Send()
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestOfflineSigningOrder verifies that signatures of other transactions and
+// payload signatures after the envelope are rejected.
+func TestOfflineSigningOrder(t *testing.T) {
+	g := glowtest.New(t)
+	svc := g.Client.SvcAcct
+	user := g.Account("test")
+
+	build := func() *client.SignedTx {
+		tx, err := g.Client.NewTxFromString(`transaction { prepare(signer: AuthAccount) {} }`, user).
+			Payer(svc).
+			Build()
+		require.NoError(t, err)
+		return tx
+	}
+
+	tx := build()
+	other, err := g.Client.NewTxFromString(`transaction { prepare(signer: AuthAccount) { log("other") } }`, user).
+		Payer(svc).
+		Build()
+	require.NoError(t, err)
+	require.NoError(t, other.SignWith(user))
+	exported, err := other.Export(client.TX_FORMAT_RLP)
+	require.NoError(t, err)
+	assert.ErrorContains(t, tx.AddSignature(exported), "different transaction")
+
+	require.NoError(t, tx.SignWith(svc))
+	assert.ErrorIs(t, tx.SignWith(user), client.ErrPayloadAfterEnvelope)
+
+	// signed in order, the transaction goes through
+	tx = build()
+	require.NoError(t, tx.SignWith(user))
+	require.NoError(t, tx.SignWith(svc))
+	missing, err := tx.MissingSignatures()
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+	_, err = tx.Send()
+	require.NoError(t, err)
+}
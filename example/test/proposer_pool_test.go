@@ -0,0 +1,69 @@
+package test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProposerPool verifies sending many transactions concurrently from one account.
+func TestProposerPool(t *testing.T) {
+	g := glowtest.New(t)
+	svc := g.Client.SvcAcct
+
+	pool, err := g.Client.NewProposerPool(svc, 10)
+	require.NoError(t, err)
+	require.Len(t, pool.Keys(), 10)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := g.Client.NewTxFromString(TX_SIGNED, svc).ProposerPool(pool).SignAndSend()
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	onChain, err := g.Client.GetAccount(svc.Address)
+	require.NoError(t, err)
+	var proposed uint64
+	for _, index := range pool.Keys() {
+		proposed += onChain.Keys[index].SequenceNumber
+	}
+	assert.Equal(t, uint64(100), proposed)
+}
+
+// TestProposerPoolResync verifies recovering from a pool key used outside the pool.
+func TestProposerPoolResync(t *testing.T) {
+	g := glowtest.New(t)
+	svc := g.Client.SvcAcct
+
+	pool, err := g.Client.NewProposerPool(svc, 1)
+	require.NoError(t, err)
+	index := pool.Keys()[0]
+
+	_, err = g.Client.NewTxFromString(TX_SIGNED, svc).ProposerPool(pool).SignAndSend()
+	require.NoError(t, err)
+
+	// the pool's sequence number of the key falls behind
+	_, err = g.Client.NewTxFromString(TX_SIGNED, pool.Account().WithKeyIndex(index)).SignAndSend()
+	require.NoError(t, err)
+
+	_, err = g.Client.NewTxFromString(TX_SIGNED, svc).ProposerPool(pool).SignAndSend()
+	require.NoError(t, err)
+
+	onChain, err := g.Client.GetAccount(svc.Address)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), onChain.Keys[index].SequenceNumber)
+}
@@ -0,0 +1,80 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSendAsync verifies sending without waiting and waiting for each status.
+func TestSendAsync(t *testing.T) {
+	g := glowtest.New(t)
+	user := g.Account("test")
+
+	tx, err := g.Client.NewTxFromString(TX_SIGNED, user).Sign()
+	require.NoError(t, err)
+
+	_, err = tx.WaitFor(client.TX_STATUS_SEALED)
+	assert.ErrorContains(t, err, "not been sent")
+
+	id, err := tx.SendAsync()
+	require.NoError(t, err)
+	assert.Equal(t, id, tx.ID())
+
+	for _, status := range []flow.TransactionStatus{client.TX_STATUS_FINALIZED, client.TX_STATUS_EXECUTED, client.TX_STATUS_SEALED} {
+		res, err := tx.WaitFor(status)
+		require.NoError(t, err)
+		assert.Equal(t, id, res.ID())
+		assert.GreaterOrEqual(t, res.Status, status)
+	}
+
+	_, err = tx.WaitFor(flow.TransactionStatusPending)
+	assert.Error(t, err)
+
+	// a done context stops the transaction from being sent
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tx, err = g.Client.NewTxFromString(TX_SIGNED, user).WithContext(ctx).Sign()
+	require.NoError(t, err)
+	_, err = tx.SendAsync()
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestSendTo verifies collecting the results of a batch of transactions from one channel.
+func TestSendTo(t *testing.T) {
+	g := glowtest.New(t)
+	user := g.Account("test")
+
+	results := make(chan client.TxOutcome)
+	sent := map[flow.Identifier]bool{}
+	for i := 0; i < 5; i++ {
+		code := TX_SIGNED
+		if i == 2 {
+			code = `transaction { prepare(signer: AuthAccount) { panic("third fails") } }`
+		}
+		tx, err := g.Client.NewTxFromString(code, user).Sign()
+		require.NoError(t, err)
+		id, err := tx.SendTo(results, client.TX_STATUS_SEALED)
+		require.NoError(t, err)
+		sent[id] = i == 2
+	}
+
+	for range sent {
+		outcome := <-results
+		fails, ok := sent[outcome.ID]
+		require.True(t, ok)
+		if fails {
+			var cErr *client.CadenceError
+			require.ErrorAs(t, outcome.Err, &cErr)
+			assert.Equal(t, "third fails", cErr.Panic)
+			continue
+		}
+		require.NoError(t, outcome.Err)
+		assert.Equal(t, flow.TransactionStatusSealed, outcome.Result.Status)
+	}
+}
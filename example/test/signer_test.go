@@ -0,0 +1,160 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/rrossilli/glow/internal/kmstest"
+	"github.com/rrossilli/glow/model"
+	"github.com/rrossilli/glow/signer"
+	"github.com/rrossilli/glow/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const TX_SIGNED = `transaction { prepare(signer: AuthAccount) {} }`
+
+// TestFileSigners verifies that flow.json accounts sign with key files and encrypted keystores.
+func TestFileSigners(t *testing.T) {
+	fileKey := newKey(t, crypto.ECDSA_P256, crypto.SHA3_256, 1000)
+	keystoreKey := newKey(t, crypto.ECDSA_secp256k1, crypto.SHA2_256, 1000)
+
+	pk, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_secp256k1, util.RemoveHexPrefix(keystoreKey.PrivateKey))
+	require.NoError(t, err)
+	keystore, err := signer.EncryptKeystore(pk, "correct horse")
+	require.NoError(t, err)
+	t.Setenv("TEST_KEYSTORE_PASSPHRASE", "correct horse")
+
+	cfg := emulatorProject(nil)
+	accounts := cfg["accounts"].(map[string]interface{})
+	accounts["emulator-file"] = map[string]interface{}{
+		"address": model.EmulatorAddress(5).Hex(),
+		"key":     map[string]string{"type": "file", "location": "keys/file.key"},
+	}
+	accounts["emulator-keystore"] = map[string]interface{}{
+		"address": model.EmulatorAddress(6).Hex(),
+		"key": map[string]interface{}{
+			"type":               "file",
+			"location":           "keys/keystore.json",
+			"signatureAlgorithm": "ECDSA_secp256k1",
+			"hashAlgorithm":      "SHA2_256",
+			"context":            map[string]string{"passphraseEnv": "TEST_KEYSTORE_PASSPHRASE"},
+		},
+	}
+	g := glowtest.NewWithRoot(t, WriteProject(t, cfg, map[string]string{
+		"keys/file.key":      fileKey.PrivateKey,
+		"keys/keystore.json": string(keystore),
+	}))
+
+	for _, name := range []string{"file", "keystore"} {
+		_, err := g.Client.NewTxFromString(TX_SIGNED, g.Account(name)).SignAndSend()
+		assert.NoError(t, err, name)
+	}
+
+	_, err = signer.DecryptKeystore(keystore, "wrong")
+	assert.Error(t, err)
+
+	// scrypt parameters too costly to derive are rejected up front
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(keystore, &fields))
+	fields["n"] = 1 << 30
+	hostile, err := json.Marshal(fields)
+	require.NoError(t, err)
+	_, err = signer.DecryptKeystore(hostile, "correct horse")
+	assert.ErrorContains(t, err, "exceed the limits")
+}
+
+// TestSocketSigner verifies signing by an external process over a unix socket.
+func TestSocketSigner(t *testing.T) {
+	g := glowtest.New(t)
+
+	key := newKey(t, crypto.ECDSA_P256, crypto.SHA3_256, 1000)
+	local, err := signer.NewInMemory(key.PrivateKey, crypto.ECDSA_P256, crypto.SHA3_256)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "signer.sock")
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go signer.ServeSocket(l, local)
+
+	s, err := signer.NewSocket(path)
+	require.NoError(t, err)
+	assert.True(t, s.PublicKey().Equals(local.PublicKey()))
+
+	acct, err := g.Client.CreateAccountWithSigner(s)
+	require.NoError(t, err)
+	_, err = g.Client.NewTxFromString(TX_SIGNED, *acct).SignAndSend()
+	require.NoError(t, err)
+
+	// the signer also signs for one of several keys
+	multi, err := g.Client.CreateAccountWithKeys(
+		newKey(t, crypto.ECDSA_P256, crypto.SHA3_256, 500),
+		model.AccountKey{Signer: s, Weight: 500},
+	)
+	require.NoError(t, err)
+	_, err = g.Client.NewTxFromString(TX_SIGNED, multi.WithSigningKeys(0, 1)).SignAndSend()
+	require.NoError(t, err)
+}
+
+// TestKMSSigner verifies signing with a KMS signer against a local stand-in.
+func TestKMSSigner(t *testing.T) {
+	g := glowtest.New(t)
+
+	kms, err := kmstest.Start("127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(kms.Stop)
+
+	resourceID := "projects/glow/locations/global/keyRings/test/cryptoKeys/signer/cryptoKeyVersions/1"
+	pubKey, err := kms.CreateKey(resourceID)
+	require.NoError(t, err)
+
+	s, err := signer.NewKMS(context.Background(), resourceID, kms.Addr())
+	require.NoError(t, err)
+	assert.True(t, s.PublicKey().Equals(pubKey))
+	assert.Equal(t, crypto.SHA2_256, s.HashAlgorithm())
+
+	acct, err := g.Client.CreateAccountWithSigner(s)
+	require.NoError(t, err)
+	_, err = g.Client.NewTxFromString(TX_SIGNED, *acct).SignAndSend()
+	require.NoError(t, err)
+
+	msg := []byte("some_data")
+	sig, err := acct.SignMessage(msg)
+	require.NoError(t, err)
+	hasher, err := crypto.NewHasher(crypto.SHA2_256)
+	require.NoError(t, err)
+	valid, err := pubKey.Verify(sig, append(flow.UserDomainTag[:], msg...), hasher)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = signer.NewKMS(context.Background(), resourceID+"0", kms.Addr())
+	assert.Error(t, err)
+
+	// closing the signer closes its connection
+	require.NoError(t, signer.Close(s))
+	_, err = s.Sign(msg)
+	assert.Error(t, err)
+}
+
+// TestSignerMissingFile verifies that an account whose key file is missing fails startup.
+func TestSignerMissingFile(t *testing.T) {
+	cfg := emulatorProject(nil)
+	cfg["accounts"].(map[string]interface{})["emulator-file"] = map[string]interface{}{
+		"address": model.EmulatorAddress(5).Hex(),
+		"key":     map[string]string{"type": "file", "location": "missing.key"},
+	}
+
+	_, err := client.NewGlowClientBuilder(client.NETWORK_EMBEDDED, WriteProject(t, cfg, nil), 0).StartE()
+	var acctErr *client.AccountError
+	require.ErrorAs(t, err, &acctErr)
+	assert.Equal(t, "emulator-file", acctErr.Account)
+	assert.ErrorContains(t, err, "missing.key")
+}
@@ -0,0 +1,113 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/rrossilli/glow/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const TX_AUTHORIZERS = `
+transaction(expected: [Address]) {
+	prepare(a: AuthAccount, b: AuthAccount) {
+		assert(a.address == expected[0] && b.address == expected[1], message: "unexpected authorizers")
+	}
+}`
+
+// signatures returns the payload and envelope signing keys of a signed transaction.
+func signatures(tx *flow.Transaction) (payload, envelope []string) {
+	for _, s := range tx.PayloadSignatures {
+		payload = append(payload, fmt.Sprintf("%s/%d", s.Address.Hex(), s.KeyIndex))
+	}
+	for _, s := range tx.EnvelopeSignatures {
+		envelope = append(envelope, fmt.Sprintf("%s/%d", s.Address.Hex(), s.KeyIndex))
+	}
+	return payload, envelope
+}
+
+// TestSponsoredTx verifies that a third-party payer signs only the envelope.
+func TestSponsoredTx(t *testing.T) {
+	g := glowtest.New(t)
+	svc := g.Client.SvcAcct
+	user := g.Account("test")
+
+	signed, err := g.Client.NewTxFromString(`transaction { prepare(signer: AuthAccount) {} }`, user).
+		Payer(svc).
+		Sign()
+	require.NoError(t, err)
+
+	payload, envelope := signatures(signed.FlowTransaction())
+	assert.Equal(t, []string{user.FlowAddress().Hex() + "/0"}, payload)
+	assert.Equal(t, []string{svc.FlowAddress().Hex() + "/0"}, envelope)
+	assert.Equal(t, svc.FlowAddress(), signed.FlowTransaction().Payer)
+
+	_, err = signed.Send()
+	require.NoError(t, err)
+}
+
+// TestSigningRoles verifies that accounts with several roles sign once, in the right place.
+func TestSigningRoles(t *testing.T) {
+	g := glowtest.New(t)
+	svc := g.Client.SvcAcct
+	user := g.Account("test")
+
+	// the payer also authorizes, so it only signs the envelope
+	signed, err := g.Client.NewTxFromString(TX_AUTHORIZERS, svc,
+		cadence.NewArray([]cadence.Value{user.CadenceAddress(), svc.CadenceAddress()}),
+	).Authorizers(user, svc).Sign()
+	require.NoError(t, err)
+
+	payload, envelope := signatures(signed.FlowTransaction())
+	assert.Equal(t, []string{user.FlowAddress().Hex() + "/0"}, payload)
+	assert.Equal(t, []string{svc.FlowAddress().Hex() + "/0"}, envelope)
+	_, err = signed.Send()
+	require.NoError(t, err)
+
+	// proposer, payer and authorizers are all different accounts
+	proposer, err := g.Client.CreateDisposableAccount()
+	require.NoError(t, err)
+	g.Send(g.Client.NewTxFromString(TX_AUTHORIZERS, *proposer,
+		cadence.NewArray([]cadence.Value{user.CadenceAddress(), proposer.CadenceAddress()}),
+	).Payer(svc).Authorizers(user, *proposer))
+}
+
+// TestProposerKeyIndex verifies that roles sign with their account's key index.
+func TestProposerKeyIndex(t *testing.T) {
+	g := glowtest.New(t)
+	svc := g.Client.SvcAcct
+	user := g.Account("test")
+
+	// add the account's key a second time, at index 1
+	g.Send(g.Client.NewTxFromString(`
+transaction(publicKey: String) {
+	prepare(signer: AuthAccount) {
+		signer.keys.add(
+			publicKey: PublicKey(publicKey: publicKey.decodeHex(), signatureAlgorithm: SignatureAlgorithm.ECDSA_P256),
+			hashAlgorithm: HashAlgorithm.SHA3_256,
+			weight: 1000.0
+		)
+	}
+}`, user, cadence.String(util.RemoveHexPrefix(user.CryptoPublicKey().String()))))
+
+	signed, err := g.Client.NewTxFromString(`transaction { prepare(signer: AuthAccount) {} }`, user.WithKeyIndex(1)).
+		Payer(svc).
+		Sign()
+	require.NoError(t, err)
+
+	tx := signed.FlowTransaction()
+	assert.Equal(t, 1, tx.ProposalKey.KeyIndex)
+	payload, _ := signatures(tx)
+	assert.Equal(t, []string{user.FlowAddress().Hex() + "/1"}, payload)
+	_, err = signed.Send()
+	require.NoError(t, err)
+
+	acct, err := g.Client.FlowKit.GetAccount(context.Background(), user.FlowAddress())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), acct.Keys[1].SequenceNumber)
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/rrossilli/glow/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSnapshotRollback verifies that rolling back restores state recorded by a snapshot.
+func TestSnapshotRollback(t *testing.T) {
+	c := client.NewGlowClient().Start()
+	svc := c.SvcAcct
+
+	privKey, err := c.NewPrivateKey(GENERATE_KEYS_SEED_PHRASE)
+	require.NoError(t, err)
+	recipient, err := c.CreateAccount(privKey)
+	require.NoError(t, err)
+
+	balance := func() uint64 {
+		result, err := c.NewScFromFile(ScPath("flow_balance"), recipient.CadenceAddress()).Exec()
+		require.NoError(t, err)
+		return result.ToGoValue().(uint64)
+	}
+	transfer := func() {
+		amount, err := cadence.NewUFix64("10.0")
+		require.NoError(t, err)
+		txRes, err := c.NewTxFromFile(TxPath("flow_transfer"), svc).
+			Args(amount, recipient.CadenceAddress()).
+			SignAndSend()
+		require.NoError(t, err)
+		require.NoError(t, txRes.Error)
+	}
+
+	require.NoError(t, c.Snapshot("clean"))
+	before := balance()
+
+	// rolling back without changes is a no-op
+	require.NoError(t, c.Rollback("clean"))
+	assert.Equal(t, before, balance())
+
+	// the snapshot can be rolled back to repeatedly
+	for i := 0; i < 2; i++ {
+		transfer()
+		assert.Greater(t, balance(), before)
+
+		require.NoError(t, c.Rollback("clean"))
+		assert.Equal(t, before, balance())
+	}
+
+	assert.Error(t, c.Rollback("missing"))
+
+	// rolling back discards snapshots taken after the target
+	transfer()
+	require.NoError(t, c.Snapshot("later"))
+	require.NoError(t, c.Rollback("clean"))
+	transfer()
+	assert.Error(t, c.Rollback("later"))
+	require.NoError(t, c.Rollback("clean"))
+	assert.Equal(t, before, balance())
+}
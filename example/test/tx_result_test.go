@@ -0,0 +1,49 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/rrossilli/glow/glowtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TokensDeposited mirrors the FlowToken.TokensDeposited event.
+type TokensDeposited struct {
+	Amount    float64
+	Recipient flow.Address `cadence:"to"`
+}
+
+// TestTxResultEvents verifies event lookup and decoding on transaction results.
+func TestTxResultEvents(t *testing.T) {
+	g := glowtest.New(t)
+	recipient := g.Account("test")
+
+	amount, err := cadence.NewUFix64("10.0")
+	require.NoError(t, err)
+	res := g.Send(g.Tx("flow_transfer", g.Client.SvcAcct, amount, recipient.CadenceAddress()))
+
+	assert.Equal(t, res.TransactionID, res.ID())
+	assert.NotEqual(t, flow.EmptyID, res.ID())
+	assert.Greater(t, res.ComputationUsed, uint64(0))
+
+	assert.NotEmpty(t, res.Events("TokensDeposited"))
+	assert.Empty(t, res.Events("Deposited"))
+	assert.Equal(t, amount, res.Event("FlowToken.TokensDeposited").Field("amount"))
+	assert.Nil(t, res.Event("TokensDeposited").Field("missing"))
+
+	var deposited TokensDeposited
+	require.NoError(t, res.DecodeEvent(&deposited))
+	assert.Equal(t, 10.0, deposited.Amount)
+	assert.Equal(t, recipient.FlowAddress(), deposited.Recipient)
+
+	var wrong struct {
+		Amount bool
+	}
+	assert.Error(t, res.Event("TokensDeposited").Decode(&wrong))
+
+	_, err = res.EventE("NFTMinted")
+	assert.Error(t, err)
+}
@@ -1,6 +1,14 @@
 package test
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
 
 func TxPath(filename string) string {
 	return fmt.Sprintf("%s/%s.cdc", "/transaction", filename)
@@ -13,3 +21,21 @@ func ScPath(filename string) string {
 func ContractPath(filename string) string {
 	return fmt.Sprintf("%s/%s.cdc", "/contract", filename)
 }
+
+// WriteProject writes a flow.json built from cfg and the given files
+// (relative path to content) to a temporary root and returns the root.
+func WriteProject(t *testing.T, cfg map[string]interface{}, files map[string]string) string {
+	root := t.TempDir()
+
+	b, err := json.MarshalIndent(cfg, "", "  ")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path.Join(root, "flow.json"), b, 0644))
+
+	for name, content := range files {
+		p := path.Join(root, name)
+		require.NoError(t, os.MkdirAll(path.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0644))
+	}
+
+	return root
+}
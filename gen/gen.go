@@ -0,0 +1,378 @@
+// Package gen generates Go bindings with typed parameters and results for
+// the transactions and scripts of a Glow project. It backs the "glow gen"
+// command.
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence/runtime/ast"
+
+	"github.com/rrossilli/glow/model"
+)
+
+// Folders under the project root holding transactions and scripts, as in
+// the example project.
+const (
+	DEFAULT_TX_DIR     = "transaction"
+	DEFAULT_SCRIPT_DIR = "script"
+)
+
+// HEADER starts every generated file, marking it as generated for Go tools.
+const HEADER = "// Code generated by glow gen. DO NOT EDIT."
+
+// ErrStale is returned by Check when the bindings do not match the Cadence files.
+var ErrStale = errors.New("bindings are out of date; run glow gen")
+
+// initialisms are kept upper case in generated names.
+var initialisms = map[string]bool{
+	"ID": true, "NFT": true, "FT": true, "URL": true, "URI": true,
+	"UUID": true, "JSON": true, "HTTP": true, "API": true, "KYC": true,
+}
+
+// Config selects the Cadence files bound and the package of the bindings.
+type Config struct {
+	Root      string // project root, holding flow.json
+	Package   string // package name of the generated file
+	TxDir     string // transactions folder under Root, DEFAULT_TX_DIR if empty
+	ScriptDir string // scripts folder under Root, DEFAULT_SCRIPT_DIR if empty
+}
+
+// FileError names the Cadence file that bindings could not be generated for.
+type FileError struct {
+	Path string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("glow gen: %s: %v", e.Path, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// binding is a generated function.
+type binding struct {
+	name    string // Go function name
+	file    string // Cadence file, relative to the project root
+	script  bool
+	signers []string // names of the transaction's AuthAccount parameters
+	params  []param
+	result  *goType // script result, nil if it returns Void
+}
+
+type param struct {
+	name string
+	typ  goType
+}
+
+// Generate parses every .cdc file in the transactions and scripts folders
+// and returns gofmt-ed Go source declaring one function per file:
+// transactions are bound to functions returning a *client.Tx with their
+// arguments set, and scripts to functions executing them and returning
+// their typed result.
+func Generate(cfg Config) ([]byte, error) {
+	if cfg.TxDir == "" {
+		cfg.TxDir = DEFAULT_TX_DIR
+	}
+	if cfg.ScriptDir == "" {
+		cfg.ScriptDir = DEFAULT_SCRIPT_DIR
+	}
+	if cfg.Package == "" {
+		return nil, fmt.Errorf("glow gen: no package name")
+	}
+
+	var bindings []binding
+	names := map[string]string{}
+	for _, dir := range []struct {
+		name   string
+		script bool
+	}{{cfg.TxDir, false}, {cfg.ScriptDir, true}} {
+		files, err := filepath.Glob(filepath.Join(cfg.Root, dir.name, "*.cdc"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+
+		for _, f := range files {
+			rel := path.Join(dir.name, filepath.Base(f))
+			b, err := bind(f, rel, dir.script)
+			if err != nil {
+				return nil, &FileError{Path: rel, Err: err}
+			}
+			if other, ok := names[b.name]; ok {
+				return nil, &FileError{Path: rel, Err: fmt.Errorf("function %s is already bound to %s", b.name, other)}
+			}
+			names[b.name] = rel
+			bindings = append(bindings, b)
+		}
+	}
+
+	return render(cfg.Package, bindings)
+}
+
+// Check reports ErrStale if the bindings in file differ from those Generate returns.
+func Check(cfg Config, file string) error {
+	want, err := Generate(cfg)
+	if err != nil {
+		return err
+	}
+	got, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("%s: %w", file, ErrStale)
+	}
+	return nil
+}
+
+// bind parses a transaction or script and describes its function.
+func bind(file, rel string, script bool) (binding, error) {
+	code, err := os.ReadFile(file)
+	if err != nil {
+		return binding{}, err
+	}
+	program, err := model.ParseCadence(code)
+	if err != nil {
+		return binding{}, err
+	}
+
+	b := binding{
+		name:   goName(strings.TrimSuffix(filepath.Base(file), ".cdc")),
+		file:   "/" + rel,
+		script: script,
+	}
+
+	var params *ast.ParameterList
+	if script {
+		main := findMain(program)
+		if main == nil {
+			return binding{}, fmt.Errorf("no main function")
+		}
+		params = main.ParameterList
+		if ret := main.ReturnTypeAnnotation; ret != nil && !isVoid(ret.Type) {
+			result := bindType(ret.Type)
+			b.result = &result
+		}
+	} else {
+		txs := program.TransactionDeclarations()
+		if len(txs) != 1 {
+			return binding{}, fmt.Errorf("expected one transaction, found %d", len(txs))
+		}
+		tx := txs[0]
+		params = tx.ParameterList
+		if tx.Prepare != nil {
+			for _, p := range tx.Prepare.FunctionDeclaration.ParameterList.Parameters {
+				b.signers = append(b.signers, p.Identifier.Identifier)
+			}
+		}
+	}
+
+	taken := map[string]bool{"c": true, "tx": true, "sc": true, "res": true, "result": true, "err": true}
+	for i, s := range b.signers {
+		b.signers[i] = uniqueName(s, taken)
+	}
+	if len(b.signers) == 0 {
+		b.signers = nil
+	}
+	if params != nil {
+		for _, p := range params.Parameters {
+			b.params = append(b.params, param{
+				name: uniqueName(p.Identifier.Identifier, taken),
+				typ:  bindType(p.TypeAnnotation.Type),
+			})
+		}
+	}
+
+	return b, nil
+}
+
+func findMain(program *ast.Program) *ast.FunctionDeclaration {
+	for _, f := range program.FunctionDeclarations() {
+		if f.Identifier.Identifier == "main" {
+			return f
+		}
+	}
+	return nil
+}
+
+func isVoid(t ast.Type) bool {
+	n, ok := t.(*ast.NominalType)
+	return ok && n.Identifier.Identifier == "Void"
+}
+
+// uniqueName returns name, or name with underscores appended if it is a Go
+// keyword or already taken.
+func uniqueName(name string, taken map[string]bool) string {
+	for token.IsKeyword(name) || taken[name] {
+		name += "_"
+	}
+	taken[name] = true
+	return name
+}
+
+// goName turns a file name such as nft_mint or get_ids into an exported Go
+// name such as NFTMint or GetIDs.
+func goName(file string) string {
+	var sb strings.Builder
+	for _, word := range strings.FieldsFunc(file, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			sb.WriteString(upper)
+			continue
+		}
+		if plural := strings.TrimSuffix(word, "s"); plural != word && initialisms[strings.ToUpper(plural)] {
+			sb.WriteString(strings.ToUpper(plural) + "s")
+			continue
+		}
+		r := []rune(word)
+		sb.WriteString(strings.ToUpper(string(r[0])) + string(r[1:]))
+	}
+
+	name := sb.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "Cdc" + name
+	}
+	return name
+}
+
+// render writes the bindings as a Go file.
+func render(pkg string, bindings []binding) ([]byte, error) {
+	var body bytes.Buffer
+	for _, b := range bindings {
+		if b.script {
+			renderScript(&body, b)
+		} else {
+			renderTx(&body, b)
+		}
+	}
+
+	var results bool
+	for _, b := range bindings {
+		results = results || b.result != nil
+	}
+	if results {
+		body.WriteString(`
+// recoverResult turns a script result of an unexpected type into an error.
+func recoverResult(name string, err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%s: unexpected result, regenerate the bindings: %v", name, r)
+	}
+}
+`)
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "%s\n\npackage %s\n\nimport (\n", HEADER, pkg)
+	for i, group := range [][]struct{ path, ident string }{
+		{{"fmt", "fmt"}},
+		{{"github.com/onflow/cadence", "cadence"}, {"github.com/onflow/flow-go-sdk", "flow"}},
+		{{"github.com/rrossilli/glow/client", "client"}, {"github.com/rrossilli/glow/model", "model"}},
+	} {
+		if i > 0 {
+			src.WriteString("\n")
+		}
+		for _, imp := range group {
+			used := regexp.MustCompile(`\b` + imp.ident + `\.[A-Z]`)
+			if used.Match(body.Bytes()) {
+				fmt.Fprintf(&src, "\t%q\n", imp.path)
+			}
+		}
+	}
+	src.WriteString(")\n")
+	src.Write(body.Bytes())
+
+	out, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("glow gen: format bindings: %w", err)
+	}
+	return out, nil
+}
+
+func renderTx(w *bytes.Buffer, b binding) {
+	signers := b.signers
+	if signers == nil {
+		signers = []string{"proposer"}
+	}
+
+	if len(b.params) > 0 {
+		fmt.Fprintf(w, "\n// %s returns the transaction in %s with its arguments set.\n", b.name, b.file)
+	} else {
+		fmt.Fprintf(w, "\n// %s returns the transaction in %s.\n", b.name, b.file)
+	}
+	switch len(b.signers) {
+	case 0:
+		fmt.Fprintf(w, "// proposer proposes and pays for it; it has no authorizers.\n")
+	case 1:
+		fmt.Fprintf(w, "// %s proposes, pays for and authorizes it.\n", signers[0])
+	default:
+		fmt.Fprintf(w, "// The authorizers are %s, in order; %s proposes and pays.\n", strings.Join(signers, ", "), signers[0])
+	}
+	fmt.Fprintf(w, "// Panics if the file cannot be loaded.\n")
+
+	fmt.Fprintf(w, "func %s(c *client.GlowClient", b.name)
+	for _, s := range signers {
+		fmt.Fprintf(w, ", %s model.Account", s)
+	}
+	writeParams(w, b.params)
+	fmt.Fprintf(w, ") *client.Tx {\n")
+
+	fmt.Fprintf(w, "\treturn c.MustNewTxFromFile(%q, %s", b.file, signers[0])
+	writeArgs(w, b.params)
+	fmt.Fprintf(w, ")")
+	if len(b.signers) != 1 {
+		fmt.Fprintf(w, ".Authorizers(%s)", strings.Join(b.signers, ", "))
+	}
+	fmt.Fprintf(w, "\n}\n")
+}
+
+func renderScript(w *bytes.Buffer, b binding) {
+	fmt.Fprintf(w, "\n// %s executes the script in %s.\n", b.name, b.file)
+	fmt.Fprintf(w, "func %s(c *client.GlowClient", b.name)
+	writeParams(w, b.params)
+	if b.result == nil {
+		fmt.Fprintf(w, ") error {\n")
+		fmt.Fprintf(w, "\tsc, err := c.NewScFromFileE(%q", b.file)
+		writeArgs(w, b.params)
+		fmt.Fprintf(w, ")\n\tif err != nil {\n\t\treturn err\n\t}\n")
+		fmt.Fprintf(w, "\t_, err = sc.Exec()\n\treturn err\n}\n")
+		return
+	}
+
+	fmt.Fprintf(w, ") (result %s, err error) {\n", b.result.name)
+	fmt.Fprintf(w, "\tsc, err := c.NewScFromFileE(%q", b.file)
+	writeArgs(w, b.params)
+	fmt.Fprintf(w, ")\n\tif err != nil {\n\t\treturn result, err\n\t}\n")
+	fmt.Fprintf(w, "\tres, err := sc.Exec()\n\tif err != nil {\n\t\treturn result, err\n\t}\n")
+	fmt.Fprintf(w, "\tdefer recoverResult(%q, &err)\n", b.name)
+	fmt.Fprintf(w, "\treturn %s, nil\n}\n", fill(b.result.fromCadence, "res"))
+}
+
+func writeParams(w *bytes.Buffer, params []param) {
+	for _, p := range params {
+		fmt.Fprintf(w, ", %s %s", p.name, p.typ.name)
+	}
+}
+
+func writeArgs(w *bytes.Buffer, params []param) {
+	for _, p := range params {
+		fmt.Fprintf(w, ",\n\t\t%s", fill(p.typ.toCadence, p.name))
+	}
+	if len(params) > 0 {
+		fmt.Fprintf(w, ",\n\t")
+	}
+}
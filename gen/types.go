@@ -0,0 +1,133 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+)
+
+// goType is the Go type a Cadence type is bound to, with the expressions
+// converting a Go value to a cadence.Value and back. In the formats, %s is
+// the value being converted.
+type goType struct {
+	name        string
+	toCadence   string
+	fromCadence string
+	comparable  bool // usable as a map key
+}
+
+// opaque passes values of Cadence types without a Go binding as they are.
+var opaque = goType{name: "cadence.Value", toCadence: "%s", fromCadence: "%s"}
+
+// Go types of Cadence's simple types.
+var simpleTypes = map[string]goType{
+	"Address":   {"flow.Address", "cadence.Address(%s)", "flow.Address(%s.(cadence.Address))", true},
+	"String":    {"string", "cadence.String(%s)", "string(%s.(cadence.String))", true},
+	"Character": {"string", "cadence.Character(%s)", "string(%s.(cadence.Character))", true},
+	"Bool":      {"bool", "cadence.Bool(%s)", "bool(%s.(cadence.Bool))", true},
+	"Int":       {"int", "cadence.NewInt(%s)", "%s.(cadence.Int).Int()", true},
+	"UInt":      {"uint", "cadence.NewUInt(%s)", "uint(%s.(cadence.UInt).Big().Uint64())", true},
+}
+
+func init() {
+	for _, bits := range []string{"8", "16", "32", "64"} {
+		for cdc, goName := range map[string]string{"Int": "int", "UInt": "uint", "Word": "uint"} {
+			simpleTypes[cdc+bits] = goType{
+				name:        goName + bits,
+				toCadence:   "cadence." + cdc + bits + "(%s)",
+				fromCadence: goName + bits + "(%s.(cadence." + cdc + bits + "))",
+				comparable:  true,
+			}
+		}
+	}
+
+	// Cadence's own Go types, for values with no plain Go equivalent
+	for _, cdc := range []string{
+		"Fix64", "UFix64",
+		"Int128", "Int256", "UInt128", "UInt256", "Word128", "Word256",
+	} {
+		simpleTypes[cdc] = goType{"cadence." + cdc, "%s", "%s.(cadence." + cdc + ")", true}
+	}
+	for _, cdc := range []string{"Path", "StoragePath", "PublicPath", "PrivatePath", "CapabilityPath"} {
+		simpleTypes[cdc] = goType{"cadence.Path", "%s", "%s.(cadence.Path)", true}
+	}
+}
+
+// bindType returns the Go type of a Cadence parameter or return type.
+// Arrays, optionals and dictionaries of bound types are bound to slices,
+// pointers and maps; other types are passed as cadence.Value.
+func bindType(t ast.Type) goType {
+	switch t := t.(type) {
+	case *ast.NominalType:
+		if len(t.NestedIdentifiers) == 0 {
+			if gt, ok := simpleTypes[t.Identifier.Identifier]; ok {
+				return gt
+			}
+		}
+
+	case *ast.VariableSizedType:
+		return sliceType(bindType(t.Type))
+
+	case *ast.ConstantSizedType:
+		return sliceType(bindType(t.Type))
+
+	case *ast.OptionalType:
+		elem := bindType(t.Type)
+		if elem == opaque {
+			return opaque
+		}
+		return goType{
+			name: "*" + elem.name,
+			toCadence: fmt.Sprintf(
+				"func(x *%s) cadence.Value { if x == nil { return cadence.NewOptional(nil) }; return cadence.NewOptional(%s) }(%%s)",
+				elem.name, fill(elem.toCadence, "*x"),
+			),
+			fromCadence: fmt.Sprintf(
+				"func(v cadence.Value) *%s { o := v.(cadence.Optional); if o.Value == nil { return nil }; x := %s; return &x }(%%s)",
+				elem.name, fill(elem.fromCadence, "o.Value"),
+			),
+		}
+
+	case *ast.DictionaryType:
+		key, value := bindType(t.KeyType), bindType(t.ValueType)
+		if !key.comparable {
+			return opaque
+		}
+		name := fmt.Sprintf("map[%s]%s", key.name, value.name)
+		return goType{
+			name: name,
+			toCadence: fmt.Sprintf(
+				"func(m %s) cadence.Value { pairs := make([]cadence.KeyValuePair, 0, len(m)); for k, v := range m { pairs = append(pairs, cadence.KeyValuePair{Key: %s, Value: %s}) }; return cadence.NewDictionary(pairs) }(%%s)",
+				name, fill(key.toCadence, "k"), fill(value.toCadence, "v"),
+			),
+			fromCadence: fmt.Sprintf(
+				"func(v cadence.Value) %s { d := v.(cadence.Dictionary); m := make(%s, len(d.Pairs)); for _, p := range d.Pairs { m[%s] = %s }; return m }(%%s)",
+				name, name, fill(key.fromCadence, "p.Key"), fill(value.fromCadence, "p.Value"),
+			),
+		}
+	}
+
+	return opaque
+}
+
+// sliceType binds a Cadence array of elem.
+func sliceType(elem goType) goType {
+	name := "[]" + elem.name
+	return goType{
+		name: name,
+		toCadence: fmt.Sprintf(
+			"func(xs %s) cadence.Value { vs := make([]cadence.Value, len(xs)); for i, x := range xs { vs[i] = %s }; return cadence.NewArray(vs) }(%%s)",
+			name, fill(elem.toCadence, "x"),
+		),
+		fromCadence: fmt.Sprintf(
+			"func(v cadence.Value) %s { a := v.(cadence.Array); xs := make(%s, len(a.Values)); for i, v := range a.Values { xs[i] = %s }; return xs }(%%s)",
+			name, name, fill(elem.fromCadence, "v"),
+		),
+	}
+}
+
+// fill fills a conversion format with the value being converted.
+func fill(f, value string) string {
+	return strings.ReplaceAll(f, "%s", value)
+}
@@ -0,0 +1,192 @@
+package glowtest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rrossilli/glow/client"
+)
+
+// ExpectedEvent is an event expected by AssertEventsInOrder.
+type ExpectedEvent struct {
+	Type   string         // type or dot-separated type suffix, as for TxResult.Events
+	Fields map[string]any // fields to match; others are ignored
+}
+
+// AssertEventEmitted asserts that the transaction emitted an event of the
+// given type whose fields include the expected ones. Expected values are
+// either cadence values or Go values the Cadence field decodes to (see
+// client.Event.Decode); nil matches an empty optional.
+func AssertEventEmitted(t assert.TestingT, res *client.TxResult, eventType string, fields map[string]any, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	events := res.Events(eventType)
+	for _, e := range events {
+		if len(mismatches(e, fields)) == 0 {
+			return true
+		}
+	}
+
+	return assert.Fail(t, fmt.Sprintf(
+		"no %s event matches %s\nemitted %s events:\n%s",
+		eventType, formatExpected(fields), eventType, formatActual(events, fields),
+	), msgAndArgs...)
+}
+
+// AssertEventCount asserts that the transaction emitted exactly n events of the given type.
+func AssertEventCount(t assert.TestingT, res *client.TxResult, eventType string, n int, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	events := res.Events(eventType)
+	if len(events) == n {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf(
+		"expected %d %s event(s), got %d\n%s", n, eventType, len(events), formatActual(events, nil),
+	), msgAndArgs...)
+}
+
+// AssertNoEvent asserts that the transaction emitted no event of the given type.
+func AssertNoEvent(t assert.TestingT, res *client.TxResult, eventType string, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	events := res.Events(eventType)
+	if len(events) == 0 {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf(
+		"expected no %s event, got %d\n%s", eventType, len(events), formatActual(events, nil),
+	), msgAndArgs...)
+}
+
+// AssertEventsInOrder asserts that the transaction emitted events matching
+// the expected ones in the given order. Other events may come before,
+// between or after them.
+func AssertEventsInOrder(t assert.TestingT, res *client.TxResult, expected []ExpectedEvent, msgAndArgs ...interface{}) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	all := eventsOf(res)
+
+	next := 0
+	for _, e := range all {
+		if next == len(expected) {
+			break
+		}
+		want := expected[next]
+		if matchesType(e, want.Type) && len(mismatches(e, want.Fields)) == 0 {
+			next++
+		}
+	}
+	if next == len(expected) {
+		return true
+	}
+
+	want := expected[next]
+	return assert.Fail(t, fmt.Sprintf(
+		"matched %d of %d expected events; no %s event matching %s follows\nemitted events:\n%s",
+		next, len(expected), want.Type, formatExpected(want.Fields), formatActual(all, nil),
+	), msgAndArgs...)
+}
+
+// eventsOf returns every event emitted by the transaction, in order.
+func eventsOf(res *client.TxResult) []client.Event {
+	var events []client.Event
+	for _, e := range res.TransactionResult.Events {
+		events = append(events, client.Event{Event: e})
+	}
+	return events
+}
+
+func matchesType(e client.Event, eventType string) bool {
+	return e.Type == eventType || strings.HasSuffix(e.Type, "."+eventType)
+}
+
+// mismatches describes each expected field the event does not match, keyed by field name.
+func mismatches(e client.Event, fields map[string]any) map[string]string {
+	diffs := map[string]string{}
+	for name, want := range fields {
+		got := e.Field(name)
+		if got == nil {
+			diffs[name] = "missing"
+			continue
+		}
+		if !matches(got, want) {
+			diffs[name] = fmt.Sprintf("expected %v, actual %s", want, got)
+		}
+	}
+	return diffs
+}
+
+// matches reports whether a cadence value equals an expected cadence or Go value.
+func matches(got cadence.Value, want any) bool {
+	if opt, ok := got.(cadence.Optional); ok && opt.Value == nil {
+		return want == nil
+	}
+	if want == nil {
+		return false
+	}
+	if v, ok := want.(cadence.Value); ok {
+		if opt, ok := got.(cadence.Optional); ok {
+			if _, ok := v.(cadence.Optional); !ok {
+				got = opt.Value
+			}
+		}
+		return reflect.TypeOf(got) == reflect.TypeOf(v) && got.String() == v.String()
+	}
+
+	decoded := reflect.New(reflect.TypeOf(want))
+	if err := client.Decode(got, decoded.Interface()); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(decoded.Elem().Interface(), want)
+}
+
+func formatExpected(fields map[string]any) string {
+	var parts []string
+	for _, name := range sortedNames(fields) {
+		parts = append(parts, fmt.Sprintf("%s: %v", name, fields[name]))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// formatActual lists events with their fields and, when fields are given,
+// how each event differs from them.
+func formatActual(events []client.Event, fields map[string]any) string {
+	if len(events) == 0 {
+		return "  (none)"
+	}
+
+	var b strings.Builder
+	for _, e := range events {
+		fmt.Fprintf(&b, "  %s\n", e.Value)
+		diffs := mismatches(e, fields)
+		for _, name := range sortedNames(fields) {
+			if d, ok := diffs[name]; ok {
+				fmt.Fprintf(&b, "      %s: %s\n", name, d)
+			}
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func sortedNames(fields map[string]any) []string {
+	var names []string
+	for n := range fields {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,180 @@
+// Package glowtest runs Go tests against a shared embedded Glow client.
+//
+// The client is started once per project root and reused by every test.
+// Each test takes a snapshot when it begins and rolls back to it when it
+// ends, so tests see the freshly deployed state without restarting the
+// emulator. Tests sharing a client must not call t.Parallel.
+package glowtest
+
+import (
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/onflow/cadence"
+
+	"github.com/rrossilli/glow/client"
+	"github.com/rrossilli/glow/model"
+)
+
+// Directories, relative to the project root, that transactions and scripts
+// are resolved from by name.
+const (
+	TX_DIR = "transaction"
+	SC_DIR = "script"
+)
+
+// T is a test bound to the shared embedded client for its project root.
+type T struct {
+	testing.TB
+	Client *client.GlowClient
+}
+
+type started struct {
+	client *client.GlowClient
+	err    error
+}
+
+var (
+	clientsMu sync.Mutex
+	clients   = map[string]*started{} // project root to its started client
+)
+
+// New returns a T for the project at GLOW_ROOT, starting the embedded
+// client on first use. State changes made by the test are rolled back
+// when it ends.
+func New(t testing.TB) *T {
+	t.Helper()
+	return NewWithRoot(t, os.Getenv("GLOW_ROOT"))
+}
+
+// NewWithRoot is like New for the project at root.
+func NewWithRoot(t testing.TB, root string) *T {
+	t.Helper()
+
+	c, err := sharedClient(root)
+	if err != nil {
+		t.Fatalf("glowtest: start client for %q: %v", root, err)
+	}
+
+	snapshot := "glowtest/" + t.Name()
+	if err := c.Snapshot(snapshot); err != nil {
+		t.Fatalf("glowtest: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := c.Rollback(snapshot); err != nil {
+			t.Errorf("glowtest: %v", err)
+		}
+	})
+
+	return &T{TB: t, Client: c}
+}
+
+// sharedClient starts the embedded client for root once and returns it,
+// or the error it failed to start with, on every call.
+func sharedClient(root string) (*client.GlowClient, error) {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+
+	if s, ok := clients[root]; ok {
+		return s.client, s.err
+	}
+
+	// the log level still comes from GLOW_LOG, but the network is always embedded
+	logLvl := client.NewGlowClient().LogLvl
+	c, err := client.NewGlowClientBuilder(client.NETWORK_EMBEDDED, root, logLvl).StartE()
+	clients[root] = &started{client: c, err: err}
+	return c, err
+}
+
+// Account returns the flow.json account with the given name, failing the
+// test if it is not defined. The network prefix may be omitted, so
+// "emulator-test" can be referenced by "test".
+func (t *T) Account(name string) model.Account {
+	t.Helper()
+	a := t.Client.FlowJSON.Account(name)
+	if a.Address == "" {
+		a = t.Client.FlowJSON.Account(client.NETWORK_EMULATOR + "-" + name)
+	}
+	if a.Address == "" {
+		t.Fatalf("account %s is not defined in flow.json", name)
+	}
+	return a
+}
+
+// Tx loads a transaction by name from TX_DIR, or by path relative to the
+// project root if name ends in .cdc, failing the test if it cannot be loaded.
+func (t *T) Tx(name string, proposer model.Account, args ...cadence.Value) *client.Tx {
+	t.Helper()
+	tx, err := t.Client.NewTxFromFileE(resolve(TX_DIR, name), proposer, args...)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return tx
+}
+
+// Sc loads a script by name from SC_DIR, or by path relative to the
+// project root if name ends in .cdc, failing the test if it cannot be loaded.
+func (t *T) Sc(name string, args ...cadence.Value) *client.Sc {
+	t.Helper()
+	sc, err := t.Client.NewScFromFileE(resolve(SC_DIR, name), args...)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return sc
+}
+
+// Send signs and sends the transaction, failing the test if it does not succeed.
+func (t *T) Send(tx *client.Tx) *client.TxResult {
+	t.Helper()
+	res, err := tx.SignAndSend()
+	if err != nil {
+		t.Fatalf("transaction failed:\n%s", readable(err))
+	}
+	return res
+}
+
+// SendExpectFailure signs and sends a transaction that is expected to fail
+// with a Cadence error whose message contains substr, failing the test if
+// it succeeds or fails otherwise.
+func (t *T) SendExpectFailure(tx *client.Tx, substr string) *client.CadenceError {
+	t.Helper()
+	ce, err := tx.SignAndSendExpectFailure(substr)
+	if err != nil {
+		if ce != nil {
+			t.Fatalf("expected a failure matching %q, got:\n%s", substr, readable(ce))
+		}
+		t.Fatalf("%v", err)
+	}
+	return ce
+}
+
+// Exec executes the script, failing the test if it does not succeed.
+func (t *T) Exec(sc *client.Sc) cadence.Value {
+	t.Helper()
+	v, err := sc.Exec()
+	if err != nil {
+		t.Fatalf("script failed:\n%s", readable(err))
+	}
+	return v
+}
+
+// resolve maps a transaction or script name to its path under the project root.
+func resolve(dir, name string) string {
+	if strings.HasSuffix(name, ".cdc") {
+		return name
+	}
+	return path.Join(dir, name+".cdc")
+}
+
+// readable drops the execution error codes wrapping a Cadence error and
+// indents the rest, keeping the code excerpt aligned in test output.
+func readable(err error) string {
+	lines := strings.Split(client.NewCadenceError(err).Detail, "\n")
+	for i, l := range lines {
+		lines[i] = "    " + l
+	}
+	return strings.Join(lines, "\n")
+}
@@ -3,6 +3,7 @@ module github.com/rrossilli/glow
 go 1.20
 
 require (
+	cloud.google.com/go/kms v1.10.1
 	github.com/onflow/cadence v0.40.0
 	github.com/onflow/flow-cli/flowkit v1.4.2
 	github.com/onflow/flow-emulator v0.54.0
@@ -10,13 +11,15 @@ require (
 	github.com/rs/zerolog v1.29.1
 	github.com/spf13/afero v1.9.5
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/crypto v0.10.0
+	google.golang.org/api v0.114.0
+	google.golang.org/grpc v1.56.1
 )
 
 require (
 	cloud.google.com/go/compute v1.19.1 // indirect
 	cloud.google.com/go/compute/metadata v0.2.3 // indirect
 	cloud.google.com/go/iam v0.13.0 // indirect
-	cloud.google.com/go/kms v1.10.1 // indirect
 	github.com/FactomProject/basen v0.0.0-20150613233007-fe3947df716e // indirect
 	github.com/FactomProject/btcutilecc v0.0.0-20130527213604-d3a63a5752ec // indirect
 	github.com/beorn7/perks v1.0.1 // indirect
@@ -152,7 +155,6 @@ require (
 	go.uber.org/atomic v1.11.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	go.uber.org/zap v1.24.0 // indirect
-	golang.org/x/crypto v0.10.0 // indirect
 	golang.org/x/exp v0.0.0-20230321023759-10a507213a29 // indirect
 	golang.org/x/net v0.10.0 // indirect
 	golang.org/x/oauth2 v0.7.0 // indirect
@@ -161,10 +163,8 @@ require (
 	golang.org/x/text v0.10.0 // indirect
 	golang.org/x/xerrors v0.0.0-20220907171357-04be3eba64a2 // indirect
 	gonum.org/v1/gonum v0.13.0 // indirect
-	google.golang.org/api v0.114.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
-	google.golang.org/grpc v1.56.1 // indirect
 	google.golang.org/protobuf v1.30.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
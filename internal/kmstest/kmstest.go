@@ -0,0 +1,135 @@
+// Package kmstest provides a local stand-in for Cloud KMS for tests.
+package kmstest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"sync"
+
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server is a local stand-in for Cloud KMS, serving the GetPublicKey and
+// AsymmetricSign calls of KMS signers over plaintext gRPC. Its keys are
+// ECDSA P-256 keys with SHA2_256 hashing, held in memory. It lets tests use
+// KMS signers without a cloud project.
+type Server struct {
+	kmspb.UnimplementedKeyManagementServiceServer
+
+	listener net.Listener
+	server   *grpc.Server
+
+	mu   sync.Mutex
+	keys map[string]*ecdsa.PrivateKey // resource ID to key
+}
+
+// Start starts a Server listening on addr, e.g. "127.0.0.1:0".
+func Start(addr string) (*Server, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &Server{
+		listener: l,
+		server:   grpc.NewServer(),
+		keys:     map[string]*ecdsa.PrivateKey{},
+	}
+	kmspb.RegisterKeyManagementServiceServer(k.server, k)
+	go k.server.Serve(l)
+
+	return k, nil
+}
+
+// Addr returns the address the Server listens on, to pass to signer.NewKMS as the endpoint.
+func (k *Server) Addr() string {
+	return k.listener.Addr().String()
+}
+
+// Stop stops the Server.
+func (k *Server) Stop() {
+	k.server.Stop()
+}
+
+// CreateKey generates a key with the given resource ID and returns its public key.
+func (k *Server) CreateKey(resourceID string) (crypto.PublicKey, error) {
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mu.Lock()
+	k.keys[resourceID] = pk
+	k.mu.Unlock()
+
+	pemKey, err := publicKeyPEM(pk)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.DecodePublicKeyPEM(crypto.ECDSA_P256, pemKey)
+}
+
+func (k *Server) GetPublicKey(_ context.Context, req *kmspb.GetPublicKeyRequest) (*kmspb.PublicKey, error) {
+	pk, err := k.key(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	pemKey, err := publicKeyPEM(pk)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &kmspb.PublicKey{
+		Name:      req.Name,
+		Pem:       pemKey,
+		Algorithm: kmspb.CryptoKeyVersion_EC_SIGN_P256_SHA256,
+	}, nil
+}
+
+func (k *Server) AsymmetricSign(_ context.Context, req *kmspb.AsymmetricSignRequest) (*kmspb.AsymmetricSignResponse, error) {
+	pk, err := k.key(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := req.GetDigest().GetSha256()
+	if digest == nil {
+		sum := sha256.Sum256(req.Data)
+		digest = sum[:]
+	}
+	sig, err := ecdsa.SignASN1(rand.Reader, pk, digest)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &kmspb.AsymmetricSignResponse{Name: req.Name, Signature: sig}, nil
+}
+
+func (k *Server) key(resourceID string) (*ecdsa.PrivateKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	pk, ok := k.keys[resourceID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "key %s not found", resourceID)
+	}
+	return pk, nil
+}
+
+func publicKeyPEM(pk *ecdsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(&pk.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
@@ -1,17 +1,199 @@
 package model
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 
+	"github.com/rrossilli/glow/signer"
 	"github.com/rrossilli/glow/util"
 )
 
-// Account struct as it typically appears in a flow.json
+// Account struct as it typically appears in a flow.json. PrivKey holds the
+// hex private key from either the simple or the advanced "hex" key format.
+// Accounts created with several keys also list them all in Keys; Key and
+// PrivKey then hold the key the account proposes and signs with by default.
 type Account struct {
-	Address string `json:"address"`
-	PrivKey string `json:"key"`
+	Address     string       `json:"address"`
+	PrivKey     string       `json:"-"`
+	Key         AccountKey   `json:"-"`
+	Keys        []AccountKey `json:"-"`
+	SigningKeys []int        `json:"-"` // indexes of the keys that sign for the account, Key.Index if empty
+}
+
+// AccountKey is the advanced key format of a flow.json account. It is the
+// zero value for accounts that use the simple format.
+type AccountKey struct {
+	Type           string            `json:"type"`
+	Index          int               `json:"index,omitempty"`
+	SigAlgo        string            `json:"signatureAlgorithm,omitempty"`
+	HashAlgo       string            `json:"hashAlgorithm,omitempty"`
+	PrivateKey     string            `json:"privateKey,omitempty"`
+	Mnemonic       string            `json:"mnemonic,omitempty"`
+	DerivationPath string            `json:"derivationPath,omitempty"`
+	ResourceID     string            `json:"resourceID,omitempty"`
+	Location       string            `json:"location,omitempty"`
+	Context        map[string]string `json:"context,omitempty"`
+	Weight         int               `json:"-"` // out of 1000, for keys of accounts created with a key set
+	PublicKey      string            `json:"-"` // hex public key, for keys added without a private key
+	Revoked        bool              `json:"-"`
+	Signer         signer.Signer     `json:"-"` // signs for the key instead of the signer of its type
+	dir            string            // directory a relative Location is resolved against
+}
+
+// Key algorithms used when flow.json does not set them, as in the Flow CLI.
+const (
+	DEFAULT_SIG_ALGO  = "ECDSA_P256"
+	DEFAULT_HASH_ALGO = "SHA3_256"
+)
+
+// SigAlgorithm returns the key's signature algorithm, or
+// crypto.UnknownSignatureAlgorithm if its name is not recognised.
+func (k AccountKey) SigAlgorithm() crypto.SignatureAlgorithm {
+	if k.SigAlgo == "" {
+		return crypto.StringToSignatureAlgorithm(DEFAULT_SIG_ALGO)
+	}
+	return crypto.StringToSignatureAlgorithm(k.SigAlgo)
+}
+
+// HashAlgorithm returns the key's hash algorithm, or
+// crypto.UnknownHashAlgorithm if its name is not recognised.
+func (k AccountKey) HashAlgorithm() crypto.HashAlgorithm {
+	if k.HashAlgo == "" {
+		return crypto.StringToHashAlgorithm(DEFAULT_HASH_ALGO)
+	}
+	return crypto.StringToHashAlgorithm(k.HashAlgo)
+}
+
+// Key types supported by the advanced account format.
+const (
+	KEY_TYPE_HEX        = "hex"
+	KEY_TYPE_FILE       = "file"
+	KEY_TYPE_BIP44      = "bip44"
+	KEY_TYPE_GOOGLE_KMS = "google-kms"
+)
+
+// Keystore files are file keys whose location has this extension.
+const KEYSTORE_EXT = ".json"
+
+// Context entries of advanced keys.
+const (
+	CONTEXT_PASSPHRASE_ENV = "passphraseEnv" // variable holding a keystore's passphrase
+	CONTEXT_KMS_ENDPOINT   = "endpoint"      // KMS-compatible gRPC service of a google-kms key
+)
+
+// DEFAULT_PASSPHRASE_ENV holds the passphrase of keystores that do not name another variable.
+const DEFAULT_PASSPHRASE_ENV = "GLOW_KEYSTORE_PASSPHRASE"
+
+// ResolveSigner returns the key's Signer if set, and otherwise creates the
+// signer of its type: in memory for hex keys, from the file at Location for
+// file keys (an encrypted keystore if it ends in KEYSTORE_EXT), and Cloud
+// KMS, or the KMS-compatible service at the "endpoint" context entry, for
+// google-kms keys.
+func (k AccountKey) ResolveSigner() (signer.Signer, error) {
+	if k.Signer != nil {
+		return k.Signer, nil
+	}
+
+	sigAlgo, hashAlgo := k.SigAlgorithm(), k.HashAlgorithm()
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("unknown signature algorithm %q", k.SigAlgo)
+	}
+	if hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("unknown hash algorithm %q", k.HashAlgo)
+	}
+
+	switch k.Type {
+	case "", KEY_TYPE_HEX:
+		return signer.NewInMemory(k.PrivateKey, sigAlgo, hashAlgo)
+	case KEY_TYPE_FILE:
+		if strings.HasSuffix(k.Location, KEYSTORE_EXT) {
+			env := k.Context[CONTEXT_PASSPHRASE_ENV]
+			if env == "" {
+				env = DEFAULT_PASSPHRASE_ENV
+			}
+			return signer.NewKeystore(k.path(), os.Getenv(env), hashAlgo)
+		}
+		return signer.NewFile(k.path(), sigAlgo, hashAlgo)
+	case KEY_TYPE_GOOGLE_KMS:
+		return signer.NewKMS(context.Background(), k.ResourceID, k.Context[CONTEXT_KMS_ENDPOINT])
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Type)
+}
+
+// hasSigner reports whether the key can sign without a hex private key.
+func (k AccountKey) hasSigner() bool {
+	return k.Signer != nil || (k.Type != "" && k.Type != KEY_TYPE_HEX)
+}
+
+// path returns Location, resolved against the flow.json directory if relative.
+func (k AccountKey) path() string {
+	if k.dir == "" || filepath.IsAbs(k.Location) {
+		return k.Location
+	}
+	return filepath.Join(k.dir, k.Location)
+}
+
+type accountJSON struct {
+	Address string          `json:"address"`
+	Key     json.RawMessage `json:"key"`
+}
+
+// UnmarshalJSON parses both the simple and advanced account formats.
+func (a *Account) UnmarshalJSON(b []byte) error {
+	var raw accountJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*a = Account{Address: raw.Address}
+	if len(raw.Key) == 0 {
+		return nil
+	}
+
+	var privKey string
+	if err := json.Unmarshal(raw.Key, &privKey); err == nil {
+		a.PrivKey = privKey
+		return nil
+	}
+
+	if err := json.Unmarshal(raw.Key, &a.Key); err != nil {
+		return fmt.Errorf("account %s: invalid key: %w", raw.Address, err)
+	}
+	if a.Key.Type == "" {
+		a.Key.Type = KEY_TYPE_HEX
+	}
+	if a.Key.Type == KEY_TYPE_HEX {
+		a.PrivKey = a.Key.PrivateKey
+	}
+
+	return nil
+}
+
+// MarshalJSON writes the simple format unless the account has an advanced
+// key or key algorithms the simple format cannot express.
+func (a Account) MarshalJSON() ([]byte, error) {
+	var key interface{} = a.PrivKey
+	if a.Key.Type != "" {
+		key = a.Key
+	} else if a.Key.SigAlgo != "" || a.Key.HashAlgo != "" {
+		k := a.Key
+		k.Type = KEY_TYPE_HEX
+		k.PrivateKey = a.PrivKey
+		key = k
+	}
+
+	return json.Marshal(struct {
+		Address string      `json:"address"`
+		Key     interface{} `json:"key"`
+	}{a.Address, key})
 }
 
 // New Account
@@ -29,6 +211,111 @@ func NewUnqualifiedAccount(address string) Account {
 	}
 }
 
+// WithKeyIndex returns a copy of the account that proposes and signs with
+// the key at index.
+func (a Account) WithKeyIndex(index int) Account {
+	if k, ok := a.keyInSet(index); ok {
+		a.Key = k
+		a.PrivKey = k.PrivateKey
+	}
+	a.Key.Index = index
+	a.SigningKeys = nil
+	return a
+}
+
+// WithSigner returns a copy of the account whose key signs with s, for
+// example a signer of an external process or a KMS.
+func (a Account) WithSigner(s signer.Signer) Account {
+	a.Key.Signer = s
+	a.Keys = append([]AccountKey(nil), a.Keys...)
+	for i := range a.Keys {
+		if a.Keys[i].Index == a.Key.Index {
+			a.Keys[i].Signer = s
+		}
+	}
+	return a
+}
+
+// ResolveSigner returns the signer of the account's key. See AccountKey.ResolveSigner.
+func (a Account) ResolveSigner() (signer.Signer, error) {
+	k, ok := a.KeyAt(a.Key.Index)
+	if !ok {
+		return nil, fmt.Errorf("account %s has no key to sign with", a.Address)
+	}
+	s, err := k.ResolveSigner()
+	if err != nil {
+		return nil, fmt.Errorf("account %s: %w", a.Address, err)
+	}
+	return s, nil
+}
+
+// WithSigningKeys returns a copy of the account that signs with the keys at
+// the given indexes, e.g. a subset whose weights reach the signing threshold.
+func (a Account) WithSigningKeys(indexes ...int) Account {
+	a.SigningKeys = append([]int(nil), indexes...)
+	return a
+}
+
+// SigningKeyIndexes returns the indexes of the keys that sign for the account.
+func (a Account) SigningKeyIndexes() []int {
+	if len(a.SigningKeys) > 0 {
+		return a.SigningKeys
+	}
+	return []int{a.Key.Index}
+}
+
+// KeyAt returns the account's key at index, with its hex private key or signer.
+func (a Account) KeyAt(index int) (AccountKey, bool) {
+	if k, ok := a.keyInSet(index); ok {
+		return k, true
+	}
+	if index == a.Key.Index && (a.PrivKey != "" || a.Key.hasSigner()) {
+		k := a.Key
+		k.PrivateKey = a.PrivKey
+		return k, true
+	}
+	return AccountKey{}, false
+}
+
+// AddKey records a key added to the account on chain.
+func (a *Account) AddKey(k AccountKey) {
+	a.ensureKeySet()
+	a.Keys = append(a.Keys, k)
+}
+
+// RevokeKey records that the key at index was revoked on chain.
+func (a *Account) RevokeKey(index int) {
+	a.ensureKeySet()
+	for i := range a.Keys {
+		if a.Keys[i].Index == index {
+			a.Keys[i].Revoked = true
+		}
+	}
+	if a.Key.Index == index {
+		a.Key.Revoked = true
+	}
+}
+
+// ensureKeySet lists the single key of a flow.json account in Keys, so
+// keys added later are kept alongside it.
+func (a *Account) ensureKeySet() {
+	if len(a.Keys) > 0 {
+		return
+	}
+	if k, ok := a.KeyAt(a.Key.Index); ok {
+		a.Keys = []AccountKey{k}
+	}
+}
+
+func (a Account) keyInSet(index int) (AccountKey, bool) {
+	for _, k := range a.Keys {
+		if k.Index == index {
+			return k, true
+		}
+	}
+	return AccountKey{}, false
+}
+
 // "flow" address
 func (a Account) FlowAddress() flow.Address {
 	return flow.HexToAddress(a.Address)
@@ -39,28 +326,46 @@ func (a Account) CadenceAddress() cadence.Address {
 	return cadence.Address(a.FlowAddress())
 }
 
-// Crypto private key
-func (a Account) CryptoPrivateKey() crypto.PrivateKey {
-	key, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, util.RemoveHexPrefix(a.PrivKey))
+// MustCryptoPrivateKey decodes the account's private key like
+// CryptoPrivateKeyE, but panics if the key cannot be decoded.
+func (a Account) MustCryptoPrivateKey() crypto.PrivateKey {
+	key, err := a.CryptoPrivateKeyE()
 	if err != nil {
 		panic(err)
 	}
 	return key
 }
 
+// Crypto private key.
+//
+// Deprecated: CryptoPrivateKey panics if the key cannot be decoded; use
+// CryptoPrivateKeyE, or MustCryptoPrivateKey.
+func (a Account) CryptoPrivateKey() crypto.PrivateKey {
+	return a.MustCryptoPrivateKey()
+}
+
+// Crypto private key decoded from the account's hex key with the key's signature algorithm
+func (a Account) CryptoPrivateKeyE() (crypto.PrivateKey, error) {
+	key, err := crypto.DecodePrivateKeyHex(a.Key.SigAlgorithm(), util.RemoveHexPrefix(a.PrivKey))
+	if err != nil {
+		return nil, fmt.Errorf("decode private key: %w", err)
+	}
+	return key, nil
+}
+
 // Crypto public key
 func (a Account) CryptoPublicKey() crypto.PublicKey {
-	return a.CryptoPrivateKey().PublicKey()
+	return a.MustCryptoPrivateKey().PublicKey()
 }
 
-// Sign Message
-func (a Account) SignMessage(data []byte, hashAlgo crypto.HashAlgorithm) ([]byte, error) {
-	signer, err := crypto.NewInMemorySigner(a.CryptoPrivateKey(), hashAlgo)
+// Sign Message with the account's signer and the key's hash algorithm
+func (a Account) SignMessage(data []byte) ([]byte, error) {
+	s, err := a.ResolveSigner()
 	if err != nil {
 		return nil, err
 	}
 
-	signedData, err := flow.SignUserMessage(signer, data)
+	signedData, err := flow.SignUserMessage(s, data)
 	if err != nil {
 		return nil, err
 	}
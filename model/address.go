@@ -0,0 +1,88 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/rrossilli/glow/consts"
+)
+
+// EmulatorAddress returns the address at the given index of the emulator's
+// address sequence. Index 1 is the service account.
+func EmulatorAddress(index uint) flow.Address {
+	return flow.NewAddressGenerator(flow.Emulator).SetIndex(index).Address()
+}
+
+// emulatorAddressIndex returns the index of addr in the emulator's address
+// sequence, searching up to and including max.
+func emulatorAddressIndex(addr flow.Address, max uint) (uint, bool) {
+	gen := flow.NewAddressGenerator(flow.Emulator)
+	for i := uint(consts.EMULATOR_SVC_ADDRESS_INDEX); i <= max; i++ {
+		if gen.NextAddress() == addr {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// sortEmulatorAccounts orders the named accounts by the order in which a
+// fresh emulator would create their addresses. The service account must be
+// at the service index and every other account must be reachable by
+// creating accounts one after another, without gaps.
+func sortEmulatorAccounts(accounts map[string]Account) ([]string, error) {
+	type indexed struct {
+		name  string
+		index uint
+	}
+
+	// search past the last reachable index to tell gaps apart from foreign addresses
+	lookahead := uint(consts.EMULATOR_FIRST_ACCOUNT_ADDRESS_INDEX + len(accounts) + 256)
+
+	var sorted []indexed
+	seen := map[uint]string{}
+	for name, a := range accounts {
+		addr := a.FlowAddress()
+		index, ok := emulatorAddressIndex(addr, lookahead)
+		switch {
+		case !ok && !addr.IsValid(flow.Emulator):
+			return nil, fmt.Errorf("account %s: address %s is not an emulator address (configured for another chain?)", name, a.Address)
+		case !ok:
+			return nil, fmt.Errorf("account %s: address %s cannot be reached by creating accounts sequentially", name, a.Address)
+		case index > consts.EMULATOR_SVC_ADDRESS_INDEX && index < consts.EMULATOR_FIRST_ACCOUNT_ADDRESS_INDEX:
+			return nil, fmt.Errorf("account %s: address %s is reserved for emulator core contracts", name, a.Address)
+		}
+		if other, dup := seen[index]; dup {
+			return nil, fmt.Errorf("accounts %s and %s share address %s", other, name, a.Address)
+		}
+		seen[index] = name
+		sorted = append(sorted, indexed{name, index})
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].index < sorted[j].index })
+
+	// every account after the service account must directly follow the previous one
+	var names []string
+	next := uint(consts.EMULATOR_FIRST_ACCOUNT_ADDRESS_INDEX)
+	for _, s := range sorted {
+		names = append(names, s.name)
+		if s.index == consts.EMULATOR_SVC_ADDRESS_INDEX {
+			continue
+		}
+		if s.index != next {
+			var missing []string
+			for i := next; i < s.index; i++ {
+				missing = append(missing, "0x"+EmulatorAddress(i).Hex())
+			}
+			return nil, fmt.Errorf(
+				"account %s: address %s cannot be reached by creating accounts sequentially; accounts with addresses %s must be defined first",
+				s.name, accounts[s.name].Address, strings.Join(missing, ", "),
+			)
+		}
+		next++
+	}
+
+	return names, nil
+}
@@ -1,11 +1,43 @@
 package model
 
-import "github.com/onflow/cadence"
+import (
+	"encoding/json"
 
-// Contract represents a contract entry in flow.json.
+	"github.com/onflow/cadence"
+)
+
+// Contract represents a contract entry in flow.json. The simple format is a
+// bare source path; the advanced format adds per-network aliases.
 type Contract struct {
 	Source  string            `json:"source"`
-	Aliases map[string]string `json:"aliases"`
+	Aliases map[string]string `json:"aliases,omitempty"`
+}
+
+// UnmarshalJSON parses both the simple and advanced contract formats.
+func (c *Contract) UnmarshalJSON(b []byte) error {
+	var source string
+	if err := json.Unmarshal(b, &source); err == nil {
+		*c = Contract{Source: source}
+		return nil
+	}
+
+	type advanced Contract
+	var a advanced
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	*c = Contract(a)
+	return nil
+}
+
+// MarshalJSON writes the simple format unless aliases are set.
+func (c Contract) MarshalJSON() ([]byte, error) {
+	if len(c.Aliases) == 0 {
+		return json.Marshal(c.Source)
+	}
+
+	type advanced Contract
+	return json.Marshal(advanced(c))
 }
 
 // Address returns the contract's deployment address on the given network.
@@ -1,8 +1,73 @@
 package model
 
-type Deployment map[string][]string
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+)
+
+// Deployment maps account names to the contracts deployed on them.
+type Deployment map[string][]DeploymentContract
+
+// DeploymentContract is a contract entry in a deployment. The simple format
+// is the bare contract name; the advanced format adds JSON-Cadence init args.
+type DeploymentContract struct {
+	Name string            `json:"name"`
+	Args []json.RawMessage `json:"args,omitempty"`
+}
+
+// UnmarshalJSON parses both the simple and advanced deployment formats.
+func (d *DeploymentContract) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*d = DeploymentContract{Name: name}
+		return nil
+	}
+
+	type advanced DeploymentContract
+	var a advanced
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	*d = DeploymentContract(a)
+	return nil
+}
+
+// MarshalJSON writes the simple format unless init args are set.
+func (d DeploymentContract) MarshalJSON() ([]byte, error) {
+	if len(d.Args) == 0 {
+		return json.Marshal(d.Name)
+	}
+
+	type advanced DeploymentContract
+	return json.Marshal(advanced(d))
+}
+
+// CadenceArgs decodes the JSON-Cadence init args.
+func (d DeploymentContract) CadenceArgs() ([]cadence.Value, error) {
+	var args []cadence.Value
+	for i, raw := range d.Args {
+		arg, err := jsoncdc.Decode(nil, raw)
+		if err != nil {
+			return nil, fmt.Errorf("init argument %d: %w", i, err)
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
 
 // Get contract names in deployment
 func (d Deployment) ContractNames(account string) []string {
+	var names []string
+	for _, c := range d[account] {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// Get contracts in deployment
+func (d Deployment) Contracts(account string) []DeploymentContract {
 	return d[account]
 }
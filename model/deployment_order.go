@@ -0,0 +1,171 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ContractDeployment is a single contract deployed to a flow.json account.
+type ContractDeployment struct {
+	Account  string
+	Contract DeploymentContract
+}
+
+// MissingDependencyError is returned when a deployed contract imports a
+// contract that is neither deployed nor aliased on the network.
+type MissingDependencyError struct {
+	Contract   string
+	Dependency string
+	Source     string
+	Line       int
+	Network    string
+}
+
+func (e *MissingDependencyError) Error() string {
+	return fmt.Sprintf(
+		"contract %s imports %s (%s:%d), which is neither deployed nor aliased on %s",
+		e.Contract, e.Dependency, e.Source, e.Line, e.Network,
+	)
+}
+
+// ImportCycleError is returned when deployed contracts import each other.
+type ImportCycleError struct {
+	Cycle []string // contract names, the first repeated at the end
+}
+
+func (e *ImportCycleError) Error() string {
+	return fmt.Sprintf("import cycle between contracts: %s", strings.Join(e.Cycle, " -> "))
+}
+
+// DeploymentOrder returns the network's deployments, across all accounts,
+// ordered so that every contract comes after the contracts it imports.
+// Contracts without a dependency between them keep the account creation
+// order and the order in which they are listed in flow.json.
+func (f FlowJSON) DeploymentOrder(network string) ([]ContractDeployment, error) {
+	deployment := f.Deployment(network)
+
+	// accounts in creation order, followed by any the network doesn't list
+	acctNames, err := f.AccountNamesSorted(network)
+	if err != nil {
+		return nil, err
+	}
+	listed := map[string]bool{}
+	for _, n := range acctNames {
+		listed[n] = true
+	}
+	for _, n := range sortedKeys(deployment) {
+		if !listed[n] {
+			acctNames = append(acctNames, n)
+		}
+	}
+
+	var nodes []ContractDeployment
+	byName := map[string][]int{}
+	for _, a := range acctNames {
+		for _, dc := range deployment[a] {
+			byName[dc.Name] = append(byName[dc.Name], len(nodes))
+			nodes = append(nodes, ContractDeployment{Account: a, Contract: dc})
+		}
+	}
+
+	// edges from each contract to the deployed contracts it imports
+	deps := make([][]int, len(nodes))
+	for i, n := range nodes {
+		contract := f.Contract(n.Contract.Name)
+		code, err := os.ReadFile(filepath.Join(f.root, contract.Source))
+		if err != nil {
+			return nil, fmt.Errorf("contract %s: %w", n.Contract.Name, err)
+		}
+
+		for _, imp := range f.ParseImports(string(code)) {
+			for _, dep := range imp.ContractNames() {
+				if targets, ok := byName[dep]; ok {
+					deps[i] = append(deps[i], targets...)
+					continue
+				}
+				if f.Contract(dep).Address(network) == "" {
+					return nil, &MissingDependencyError{
+						Contract:   n.Contract.Name,
+						Dependency: dep,
+						Source:     contract.Source,
+						Line:       imp.Line,
+						Network:    network,
+					}
+				}
+			}
+		}
+	}
+
+	// repeatedly take the first contract whose imports are all deployed
+	var order []ContractDeployment
+	done := make([]bool, len(nodes))
+	for len(order) < len(nodes) {
+		next := -1
+		for i := range nodes {
+			if !done[i] && allDone(deps[i], done) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, &ImportCycleError{Cycle: findCycle(nodes, deps, done)}
+		}
+		done[next] = true
+		order = append(order, nodes[next])
+	}
+
+	return order, nil
+}
+
+func allDone(deps []int, done []bool) bool {
+	for _, d := range deps {
+		if !done[d] {
+			return false
+		}
+	}
+	return true
+}
+
+// findCycle walks undeployed imports until a contract repeats. Every
+// remaining contract has an undeployed import, so the walk always loops.
+func findCycle(nodes []ContractDeployment, deps [][]int, done []bool) []string {
+	start := 0
+	for done[start] {
+		start++
+	}
+
+	visited := map[int]int{}
+	var path []int
+	for i := start; ; {
+		if pos, ok := visited[i]; ok {
+			path = append(path[pos:], i)
+			break
+		}
+		visited[i] = len(path)
+		path = append(path, i)
+		for _, d := range deps[i] {
+			if !done[d] {
+				i = d
+				break
+			}
+		}
+	}
+
+	var cycle []string
+	for _, i := range path {
+		cycle = append(cycle, nodes[i].Contract.Name)
+	}
+	return cycle
+}
+
+func sortedKeys(d Deployment) []string {
+	var keys []string
+	for k := range d {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
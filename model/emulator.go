@@ -0,0 +1,7 @@
+package model
+
+// Emulator represents an emulator entry in flow.json.
+type Emulator struct {
+	Port           int    `json:"port"`
+	ServiceAccount string `json:"serviceAccount"`
+}
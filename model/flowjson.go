@@ -3,29 +3,87 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
-	"github.com/rrossilli/glow/consts"
+	"github.com/onflow/flow-go-sdk"
+
 	"github.com/rrossilli/glow/util"
 )
 
 // FlowJSON maps a standard flow.json structure.
 type FlowJSON struct {
-	data struct {
-		Emulator    interface{}           `json:"emulators"`
-		Contracts   map[string]Contract   `json:"contracts"`
-		Networks    map[string]string     `json:"networks"`
-		Accounts    map[string]Account    `json:"accounts"`
-		Deployments map[string]Deployment `json:"deployments"`
-	}
+	data flowJSONData
+	root string // directory contract sources are resolved against
+	raw  []byte // original bytes, used to position validation diagnostics
+}
+
+// flowJSONData holds every section of a flow.json file.
+type flowJSONData struct {
+	Emulators   map[string]Emulator   `json:"emulators,omitempty"`
+	Contracts   map[string]Contract   `json:"contracts,omitempty"`
+	Networks    map[string]Network    `json:"networks,omitempty"`
+	Accounts    map[string]Account    `json:"accounts,omitempty"`
+	Deployments map[string]Deployment `json:"deployments,omitempty"`
 }
 
 // FromBytes unmarshals FlowJSON from JSON bytes.
 func (f FlowJSON) FromBytes(b []byte) (FlowJSON, error) {
 	err := json.Unmarshal(b, &f)
+	f.raw = b
+	return f, err
+}
+
+// FromFile reads and unmarshals a flow.json file. Contract sources are
+// resolved relative to the file's directory.
+func (f FlowJSON) FromFile(file string) (FlowJSON, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return f, err
+	}
+
+	f, err = f.FromBytes(b)
+	f.root = filepath.Dir(file)
 	return f, err
 }
 
+// Root returns the directory contract sources are resolved against.
+func (f FlowJSON) Root() string {
+	return f.root
+}
+
+// UnmarshalJSON parses every flow.json section into FlowJSON.
+func (f *FlowJSON) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &f.data)
+}
+
+// MarshalJSON writes FlowJSON back out in flow.json format.
+func (f FlowJSON) MarshalJSON() ([]byte, error) {
+	return json.MarshalIndent(f.data, "", "  ")
+}
+
+// Emulator returns the named emulator configuration.
+func (f FlowJSON) Emulator(name string) Emulator {
+	return f.data.Emulators[name]
+}
+
+// Emulators returns all defined emulator configurations.
+func (f FlowJSON) Emulators() map[string]Emulator {
+	return f.data.Emulators
+}
+
+// Network returns the named network.
+func (f FlowJSON) Network(name string) Network {
+	return f.data.Networks[name]
+}
+
+// Networks returns all defined networks.
+func (f FlowJSON) Networks() map[string]Network {
+	return f.data.Networks
+}
+
 // Contract returns the named contract.
 func (f FlowJSON) Contract(name string) Contract {
 	return f.data.Contracts[name]
@@ -36,6 +94,24 @@ func (f FlowJSON) Contracts() map[string]Contract {
 	return f.data.Contracts
 }
 
+// ContractAddress returns the address of the named contract on the given
+// network: its alias if one is set, otherwise the address of the account it
+// is deployed to. Returns an empty string if neither exists.
+func (f FlowJSON) ContractAddress(name, network string) string {
+	if addr := f.Contract(name).Address(network); addr != "" {
+		return addr
+	}
+
+	for _, acctName := range sortedKeys(f.Deployment(network)) {
+		for _, dc := range f.Deployment(network)[acctName] {
+			if dc.Name == name {
+				return util.PrependHexPrefix(f.Account(acctName).Address)
+			}
+		}
+	}
+	return ""
+}
+
 // ServiceAccount returns the service account for the given network.
 func (f FlowJSON) ServiceAccount(network string) Account {
 	return f.Account(fmt.Sprintf("%s-svc", network))
@@ -43,7 +119,7 @@ func (f FlowJSON) ServiceAccount(network string) Account {
 
 // Account returns the account by name.
 func (f FlowJSON) Account(name string) Account {
-	return f.data.Accounts[name]
+	return f.withDir(f.data.Accounts[name])
 }
 
 // Accounts returns all accounts for a given network.
@@ -51,37 +127,48 @@ func (f FlowJSON) Accounts(network string) map[string]Account {
 	accounts := map[string]Account{}
 	for n, a := range f.data.Accounts {
 		if strings.Contains(n, network) {
-			accounts[n] = a
+			accounts[n] = f.withDir(a)
 		}
 	}
 	return accounts
 }
 
-// AccountsSorted returns emulator accounts in the predefined order.
-func (f FlowJSON) AccountsSorted() []Account {
+// withDir sets the directory the account's key location is resolved against.
+func (f FlowJSON) withDir(a Account) Account {
+	a.Key.dir = f.root
+	return a
+}
+
+// AccountsSorted returns the network's accounts in creation order. On the
+// emulator this is the order of the emulator's address sequence, which
+// fails if an address cannot be reached by creating accounts sequentially.
+func (f FlowJSON) AccountsSorted(network string) ([]Account, error) {
+	names, err := f.AccountNamesSorted(network)
+	if err != nil {
+		return nil, err
+	}
+
 	var sorted []Account
-	for _, addr := range consts.EMULATOR_ADDRESS_ORDER {
-		for _, a := range f.data.Accounts {
-			if util.PrependHexPrefix(a.Address) == util.PrependHexPrefix(addr) {
-				sorted = append(sorted, a)
-			}
-		}
+	for _, n := range names {
+		sorted = append(sorted, f.Account(n))
 	}
-	return sorted
+	return sorted, nil
 }
 
-// AccountNamesSorted returns emulator account names in the predefined order.
-func (f FlowJSON) AccountNamesSorted(network string) []string {
+// AccountNamesSorted returns the network's account names in creation order.
+// See AccountsSorted.
+func (f FlowJSON) AccountNamesSorted(network string) ([]string, error) {
+	accounts := f.Accounts(network)
+	if ChainID(network) == flow.Emulator {
+		return sortEmulatorAccounts(accounts)
+	}
+
 	var sorted []string
-	for _, addr := range consts.EMULATOR_ADDRESS_ORDER {
-		fmt.Printf("f.data.Accounts: %v\n", f.data.Accounts)
-		for n, a := range f.data.Accounts {
-			if util.PrependHexPrefix(a.Address) == util.PrependHexPrefix(addr) {
-				sorted = append(sorted, n)
-			}
-		}
+	for n := range accounts {
+		sorted = append(sorted, n)
 	}
-	return sorted
+	sort.Strings(sorted)
+	return sorted, nil
 }
 
 // Deployment returns the deployment configuration for the given network.
@@ -0,0 +1,167 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/cadence/runtime/parser/lexer"
+)
+
+// Kinds of Cadence import locations.
+const (
+	IMPORT_FILE        = "file"        // import A from "./A.cdc"
+	IMPORT_PLACEHOLDER = "placeholder" // import A from 0xA
+	IMPORT_ADDRESS     = "address"     // import A from 0x01cf0e2f2f715450
+	IMPORT_STRING      = "string"      // import "A"
+)
+
+var hexAddressRe = regexp.MustCompile(`^0x[0-9a-fA-F]{1,16}$`)
+
+// Import is an import declaration in Cadence code.
+type Import struct {
+	Kind     string
+	Names    []string // imported identifiers, or the contract name for string imports
+	Location string   // location as written, without quotes
+	Line     int      // 1-based line of the declaration
+	Start    int      // byte offset of the declaration
+	End      int      // byte offset just past the declaration
+}
+
+// ParseImports returns the import declarations found in Cadence code.
+// Imports of built-in contracts, such as "import Crypto", are not returned.
+// A 0x location naming one of contracts, such as 0xCafe for a contract
+// Cafe, is a placeholder even if it is also a valid address.
+func ParseImports(code string, contracts ...string) []Import {
+	src, placeholders := quotePlaceholders([]byte(code), contracts)
+
+	// the parser returns the declarations before any syntax error
+	program, _ := parser.ParseProgram(nil, src, parser.Config{})
+	if program == nil {
+		return nil
+	}
+
+	var imports []Import
+	for _, decl := range program.ImportDeclarations() {
+		imp := Import{
+			Line:  decl.StartPos.Line,
+			Start: decl.StartPos.Offset,
+			End:   decl.EndPos.Offset + 1,
+		}
+		for _, id := range decl.Identifiers {
+			imp.Names = append(imp.Names, id.Identifier)
+		}
+
+		switch loc := decl.Location.(type) {
+		case common.AddressLocation:
+			imp.Kind = IMPORT_ADDRESS
+			imp.Location = code[decl.LocationPos.Offset:imp.End]
+		case common.StringLocation:
+			imp.Location = string(loc)
+			switch {
+			case placeholders[decl.LocationPos.Offset]:
+				imp.Kind = IMPORT_PLACEHOLDER
+				imp.Location = "0x" + imp.Location
+			case len(imp.Names) == 0:
+				imp.Kind = IMPORT_STRING
+				imp.Names = []string{imp.Location}
+			default:
+				imp.Kind = IMPORT_FILE
+			}
+		default:
+			continue
+		}
+		imports = append(imports, imp)
+	}
+	return imports
+}
+
+// ParseImports returns the import declarations found in Cadence code,
+// treating 0x locations that name a contract in flow.json as placeholders.
+func (f FlowJSON) ParseImports(code string) []Import {
+	var contracts []string
+	for name := range f.data.Contracts {
+		contracts = append(contracts, name)
+	}
+	return ParseImports(code, contracts...)
+}
+
+// ParseCadence parses Cadence code, accepting 0x placeholder import
+// locations, which the Cadence parser rejects.
+func ParseCadence(code []byte) (*ast.Program, error) {
+	src, _ := quotePlaceholders(code, nil)
+	return parser.ParseProgram(nil, src, parser.Config{})
+}
+
+// quotePlaceholders returns a copy of code with each 0x placeholder import
+// location written as a string location: "from 0xA" becomes `from "A"`.
+// Both have the same length, so offsets in the copy are offsets in code.
+// Locations that are valid addresses are only rewritten if they name one
+// of contracts. It also returns the offsets of the locations it rewrote.
+func quotePlaceholders(code []byte, contracts []string) ([]byte, map[int]bool) {
+	src := append([]byte(nil), code...)
+	placeholders := map[int]bool{}
+	names := map[string]bool{}
+	for _, name := range contracts {
+		names[name] = true
+	}
+
+	tokens := lexer.Lex(code, nil)
+	defer tokens.Reclaim()
+
+	afterFrom := false
+	for {
+		t := tokens.Next()
+		switch t.Type {
+		case lexer.TokenEOF:
+			return src, placeholders
+		case lexer.TokenSpace, lexer.TokenError, lexer.TokenLineComment,
+			lexer.TokenBlockCommentStart, lexer.TokenBlockCommentContent, lexer.TokenBlockCommentEnd:
+			continue
+		}
+
+		start := t.StartPos.Offset
+		if afterFrom && t.Is(lexer.TokenHexadecimalIntegerLiteral) {
+			// the lexer splits 0xName into a number and an identifier
+			end := start + 2
+			for end < len(code) && isIdentifierByte(code[end]) {
+				end++
+			}
+			name := string(code[start+2 : end])
+			if name != "" && (names[name] || !hexAddressRe.Match(code[start:end])) {
+				src[start] = '"'
+				copy(src[start+1:], name)
+				src[end-1] = '"'
+				placeholders[start] = true
+			}
+		}
+		afterFrom = t.Is(lexer.TokenIdentifier) && string(code[start:t.EndPos.Offset+1]) == "from"
+	}
+}
+
+func isIdentifierByte(b byte) bool {
+	return b == '_' || '0' <= b && b <= '9' || 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z'
+}
+
+// String returns the declaration as written, without comments.
+func (i Import) String() string {
+	switch i.Kind {
+	case IMPORT_STRING:
+		return fmt.Sprintf("import %q", i.Location)
+	case IMPORT_FILE:
+		return fmt.Sprintf("import %s from %q", strings.Join(i.Names, ", "), i.Location)
+	}
+	return fmt.Sprintf("import %s from %s", strings.Join(i.Names, ", "), i.Location)
+}
+
+// ContractNames returns the flow.json contract names the import refers to.
+// Imports of concrete addresses do not refer to flow.json contracts.
+func (i Import) ContractNames() []string {
+	if i.Kind == IMPORT_ADDRESS {
+		return nil
+	}
+	return i.Names
+}
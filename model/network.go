@@ -0,0 +1,37 @@
+package model
+
+import "encoding/json"
+
+// Network represents a network entry in flow.json. The simple format is a
+// bare "host:port" string; the advanced format also pins the access node key.
+type Network struct {
+	Host string `json:"host"`
+	Key  string `json:"key,omitempty"`
+}
+
+// UnmarshalJSON parses both the simple and advanced network formats.
+func (n *Network) UnmarshalJSON(b []byte) error {
+	var host string
+	if err := json.Unmarshal(b, &host); err == nil {
+		*n = Network{Host: host}
+		return nil
+	}
+
+	type advanced Network
+	var a advanced
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	*n = Network(a)
+	return nil
+}
+
+// MarshalJSON writes the simple format unless a key is set.
+func (n Network) MarshalJSON() ([]byte, error) {
+	if n.Key == "" {
+		return json.Marshal(n.Host)
+	}
+
+	type advanced Network
+	return json.Marshal(advanced(n))
+}
@@ -0,0 +1,313 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/rrossilli/glow/util"
+)
+
+// Diagnostic severities.
+const (
+	SEVERITY_ERROR   = "error"
+	SEVERITY_WARNING = "warning"
+)
+
+// Diagnostic is a single problem found in flow.json by Validate.
+type Diagnostic struct {
+	Severity string
+	Path     string // location in flow.json, e.g. deployments.emulator.emulator-svc[1]
+	Message  string
+	Line     int // 1-based, 0 if unknown
+	Column   int // 1-based, 0 if unknown
+}
+
+// String formats the diagnostic as "flow.json:line:col: severity: path: message".
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("flow.json:%d:%d: %s: %s: %s", d.Line, d.Column, d.Severity, d.Path, d.Message)
+}
+
+// HasErrors reports whether any diagnostic has error severity.
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SEVERITY_ERROR {
+			return true
+		}
+	}
+	return false
+}
+
+// ChainID returns the Flow chain ID for a network name, or flow.ChainID("") if unknown.
+func ChainID(network string) flow.ChainID {
+	switch network {
+	case "emulator":
+		return flow.Emulator
+	case "testnet":
+		return flow.Testnet
+	case "mainnet":
+		return flow.Mainnet
+	}
+	return flow.ChainID("")
+}
+
+// Validate checks the configuration for the given network and returns every
+// problem found, ordered by position in flow.json.
+func (f FlowJSON) Validate(network string) []Diagnostic {
+	v := validator{
+		flowJSON:  f,
+		network:   network,
+		chainID:   ChainID(network),
+		positions: jsonPositions(f.raw),
+	}
+	v.deployments()
+	v.accounts()
+	v.aliases()
+
+	sort.SliceStable(v.diags, func(i, j int) bool {
+		if v.diags[i].Line != v.diags[j].Line {
+			return v.diags[i].Line < v.diags[j].Line
+		}
+		return v.diags[i].Column < v.diags[j].Column
+	})
+	return v.diags
+}
+
+type validator struct {
+	flowJSON  FlowJSON
+	network   string
+	chainID   flow.ChainID
+	positions map[string]int
+	diags     []Diagnostic
+}
+
+// report records a diagnostic, positioned at the closest known JSON path.
+func (v *validator) report(severity string, path []string, format string, args ...interface{}) {
+	d := Diagnostic{
+		Severity: severity,
+		Path:     joinPath(path),
+		Message:  fmt.Sprintf(format, args...),
+	}
+	for i := len(path); i > 0; i-- {
+		if offset, ok := v.positions[joinPath(path[:i])]; ok {
+			d.Line, d.Column = lineColumn(v.flowJSON.raw, offset)
+			break
+		}
+	}
+	v.diags = append(v.diags, d)
+}
+
+// deployments checks that deployments reference existing accounts and
+// contracts, and that deployed contract sources exist under the root.
+func (v *validator) deployments() {
+	deployment := v.flowJSON.Deployment(v.network)
+	for acctName, contracts := range deployment {
+		acctPath := []string{"deployments", v.network, acctName}
+		if _, ok := v.flowJSON.data.Accounts[acctName]; !ok {
+			v.report(SEVERITY_ERROR, acctPath, "account %q is not defined in accounts", acctName)
+		}
+
+		for i, dc := range contracts {
+			entryPath := append(acctPath[:3:3], fmt.Sprintf("[%d]", i))
+			contract, ok := v.flowJSON.data.Contracts[dc.Name]
+			if !ok {
+				v.report(SEVERITY_ERROR, entryPath, "contract %q is not defined in contracts", dc.Name)
+				continue
+			}
+			v.source(dc.Name, contract)
+		}
+	}
+}
+
+// source checks that a contract's source exists on disk under the project root.
+func (v *validator) source(name string, contract Contract) {
+	path := []string{"contracts", name}
+	if contract.Source == "" {
+		v.report(SEVERITY_ERROR, path, "contract has no source")
+		return
+	}
+
+	p := filepath.Join(v.flowJSON.root, contract.Source)
+	rel, err := filepath.Rel(v.flowJSON.root, p)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		v.report(SEVERITY_WARNING, path, "source %s is outside the project root", contract.Source)
+	}
+	if _, err := os.Stat(p); err != nil {
+		v.report(SEVERITY_ERROR, path, "source %s not found", contract.Source)
+	}
+}
+
+// accounts checks the keys and addresses of every account on the network.
+func (v *validator) accounts() {
+	if v.chainID == flow.Emulator {
+		if _, err := sortEmulatorAccounts(v.flowJSON.Accounts(v.network)); err != nil {
+			v.report(SEVERITY_ERROR, []string{"accounts"}, "%v", err)
+		}
+	}
+
+	for name, a := range v.flowJSON.Accounts(v.network) {
+		path := []string{"accounts", name}
+		v.address(append(path, "address"), a.Address)
+
+		keyPath := append(path[:2:2], "key")
+		if a.Key.Type != "" && a.Key.Type != KEY_TYPE_HEX {
+			continue
+		}
+
+		sigAlgo := a.Key.SigAlgorithm()
+		if sigAlgo == crypto.UnknownSignatureAlgorithm {
+			v.report(SEVERITY_ERROR, keyPath, "unknown signature algorithm %q", a.Key.SigAlgo)
+			continue
+		}
+		if a.Key.HashAlgorithm() == crypto.UnknownHashAlgorithm {
+			v.report(SEVERITY_ERROR, keyPath, "unknown hash algorithm %q", a.Key.HashAlgo)
+		}
+
+		if _, err := crypto.DecodePrivateKeyHex(sigAlgo, util.RemoveHexPrefix(a.PrivKey)); err != nil {
+			v.report(SEVERITY_ERROR, keyPath, "private key does not decode as %s: %v", sigAlgo, err)
+		}
+	}
+}
+
+// aliases checks alias addresses and that every contract imported by a
+// deployed contract is either deployed or aliased on the network.
+func (v *validator) aliases() {
+	deployed := map[string]bool{}
+	for _, contracts := range v.flowJSON.Deployment(v.network) {
+		for _, dc := range contracts {
+			deployed[dc.Name] = true
+		}
+	}
+
+	for name, c := range v.flowJSON.data.Contracts {
+		if alias, ok := c.Aliases[v.network]; ok {
+			v.address([]string{"contracts", name, "aliases", v.network}, alias)
+		}
+	}
+
+	for name := range deployed {
+		contract, ok := v.flowJSON.data.Contracts[name]
+		if !ok {
+			continue
+		}
+		code, err := os.ReadFile(filepath.Join(v.flowJSON.root, contract.Source))
+		if err != nil {
+			continue
+		}
+
+		for _, imp := range v.flowJSON.ParseImports(string(code)) {
+			for _, dep := range imp.ContractNames() {
+				path := []string{"contracts", name}
+				depContract, ok := v.flowJSON.data.Contracts[dep]
+				if !ok {
+					v.report(SEVERITY_ERROR, path, "imported contract %s (%s:%d) is not defined in contracts", dep, contract.Source, imp.Line)
+					continue
+				}
+				if !deployed[dep] && depContract.Address(v.network) == "" {
+					v.report(SEVERITY_ERROR, path, "imported contract %s (%s:%d) is neither deployed nor aliased on %s", dep, contract.Source, imp.Line, v.network)
+				}
+			}
+		}
+	}
+}
+
+// address checks that addr is a valid address on the network's chain.
+func (v *validator) address(path []string, addr string) {
+	if !hexAddressRe.MatchString(util.PrependHexPrefix(addr)) {
+		v.report(SEVERITY_ERROR, path, "invalid address %q", addr)
+		return
+	}
+	if v.chainID == "" {
+		return
+	}
+	a := flow.HexToAddress(addr)
+	if !a.IsValid(v.chainID) {
+		v.report(SEVERITY_ERROR, path, "address %s is not valid on chain %s", addr, v.chainID)
+	}
+}
+
+// joinPath joins JSON path segments, attaching array indexes to their parent.
+func joinPath(path []string) string {
+	var b strings.Builder
+	for i, p := range path {
+		if i > 0 && !strings.HasPrefix(p, "[") {
+			b.WriteString(".")
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// jsonPositions maps every object key and array element in raw JSON to
+// the byte offset where it starts.
+func jsonPositions(raw []byte) map[string]int {
+	positions := map[string]int{}
+	if len(raw) == 0 {
+		return positions
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	var walk func(path []string) error
+	walk = func(path []string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		delim, ok := tok.(json.Delim)
+		if !ok || (delim != '{' && delim != '[') {
+			return nil
+		}
+
+		for i := 0; dec.More(); i++ {
+			start := skipSeparators(raw, int(dec.InputOffset()))
+			var child []string
+			if delim == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				child = append(path[:len(path):len(path)], fmt.Sprint(key))
+			} else {
+				child = append(path[:len(path):len(path)], fmt.Sprintf("[%d]", i))
+			}
+			positions[joinPath(child)] = start
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token() // closing delimiter
+		return err
+	}
+	walk(nil)
+
+	return positions
+}
+
+// skipSeparators advances offset past whitespace, commas and colons.
+func skipSeparators(raw []byte, offset int) int {
+	for offset < len(raw) && strings.ContainsRune(" \t\r\n,:", rune(raw[offset])) {
+		offset++
+	}
+	return offset
+}
+
+// lineColumn converts a byte offset into a 1-based line and column.
+func lineColumn(raw []byte, offset int) (int, int) {
+	line, col := 1, 1
+	for _, b := range raw[:offset] {
+		if b == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
@@ -0,0 +1,149 @@
+package signer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"golang.org/x/crypto/scrypt"
+)
+
+// KEYSTORE_VERSION is the version of the keystore format written by EncryptKeystore.
+const KEYSTORE_VERSION = 1
+
+// scrypt parameters of new keystores.
+const (
+	SCRYPT_N = 1 << 15
+	SCRYPT_R = 8
+	SCRYPT_P = 1
+)
+
+// Limits on the scrypt parameters of keystores read, which set the memory
+// (128·N·r bytes) and time key derivation takes.
+const (
+	SCRYPT_MAX_MEMORY = 1 << 28 // 256 MiB
+	SCRYPT_MAX_P      = 16
+)
+
+// keystore is an encrypted private key: the key is encrypted with AES-256-GCM
+// under a key derived from a passphrase with scrypt.
+type keystore struct {
+	Version    int    `json:"version"`
+	SigAlgo    string `json:"signatureAlgorithm"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       string `json:"salt"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// EncryptKeystore encrypts a private key with a passphrase and returns the
+// keystore file contents.
+func EncryptKeystore(pk crypto.PrivateKey, passphrase string) ([]byte, error) {
+	ks := keystore{
+		Version: KEYSTORE_VERSION,
+		SigAlgo: pk.Algorithm().String(),
+		N:       SCRYPT_N,
+		R:       SCRYPT_R,
+		P:       SCRYPT_P,
+	}
+
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	gcm, err := ks.cipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	ks.Salt = hex.EncodeToString(salt)
+	ks.Nonce = hex.EncodeToString(nonce)
+	ks.Ciphertext = hex.EncodeToString(gcm.Seal(nil, nonce, pk.Encode(), nil))
+
+	return json.MarshalIndent(ks, "", "  ")
+}
+
+// DecryptKeystore decrypts the private key of a keystore file's contents.
+func DecryptKeystore(b []byte, passphrase string) (crypto.PrivateKey, error) {
+	var ks keystore
+	if err := json.Unmarshal(b, &ks); err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+	if ks.Version != KEYSTORE_VERSION {
+		return nil, fmt.Errorf("keystore: unsupported version %d", ks.Version)
+	}
+	sigAlgo := crypto.StringToSignatureAlgorithm(ks.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("keystore: unknown signature algorithm %q", ks.SigAlgo)
+	}
+
+	salt, err := hex.DecodeString(ks.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: salt: %w", err)
+	}
+	nonce, err := hex.DecodeString(ks.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: nonce: %w", err)
+	}
+	ciphertext, err := hex.DecodeString(ks.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: ciphertext: %w", err)
+	}
+
+	gcm, err := ks.cipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("keystore: invalid nonce")
+	}
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: wrong passphrase or corrupted keystore")
+	}
+
+	return crypto.DecodePrivateKey(sigAlgo, plain)
+}
+
+// NewKeystore creates a signer for an encrypted keystore file written by
+// EncryptKeystore. The key is decrypted once, when the signer is created.
+func NewKeystore(path, passphrase string, hashAlgo crypto.HashAlgorithm) (Signer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err := DecryptKeystore(b, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return NewFromPrivateKey(pk, hashAlgo)
+}
+
+// cipher derives the keystore's AES-256-GCM cipher from a passphrase.
+func (ks keystore) cipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if ks.N <= 1 || ks.R <= 0 || ks.P <= 0 || ks.P > SCRYPT_MAX_P ||
+		uint64(ks.N) > SCRYPT_MAX_MEMORY/128/uint64(ks.R) {
+		return nil, fmt.Errorf("keystore: scrypt parameters n=%d r=%d p=%d exceed the limits", ks.N, ks.R, ks.P)
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, ks.N, ks.R, ks.P, 32)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,83 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/onflow/flow-go-sdk/crypto/cloudkms"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// NewKMS creates a signer for a Cloud KMS asymmetric signing key version,
+// identified by its resource ID, e.g.
+// projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1.
+// With an empty endpoint the signer uses Google Cloud KMS and the default
+// Google credentials. Otherwise it connects, without TLS or credentials, to
+// a KMS-compatible gRPC service at endpoint, such as a KMS emulator. The signer
+// holds its connection until closed with Close.
+func NewKMS(ctx context.Context, resourceID, endpoint string) (Signer, error) {
+	key, err := cloudkms.KeyFromResourceID(resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &kmsSigner{}
+	var opts []option.ClientOption
+	if endpoint != "" {
+		s.conn, err = grpc.DialContext(ctx, endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("kms %s: %w", endpoint, err)
+		}
+		opts = append(opts, option.WithGRPCConn(s.conn))
+	}
+
+	s.client, err = cloudkms.NewClient(ctx, opts...)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	_, hashAlgo, err := s.client.GetPublicKey(ctx, key)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	ks, err := s.client.SignerForKey(ctx, key)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	s.Signer = FromCrypto(ks, hashAlgo)
+	return s, nil
+}
+
+// kmsSigner is a KMS signer along with the connection it signs over.
+type kmsSigner struct {
+	Signer
+	client *cloudkms.Client
+	conn   *grpc.ClientConn // nil for Google Cloud KMS
+}
+
+// Close closes the signer's KMS client and connection.
+func (s *kmsSigner) Close() error {
+	if s.client != nil {
+		// the KMS client closes the connection it was given
+		return s.client.KMSClient().Close()
+	}
+	if s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
+}
+
+// Close releases the resources held by s, such as the connection of a KMS
+// signer. Signers that hold none are left as they are.
+func Close(s Signer) error {
+	if c, ok := s.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Package signer provides the signers accounts sign transactions and
+// messages with: in-memory hex keys, key files, encrypted keystores,
+// external processes on a local socket and KMS-compatible services.
+package signer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/rrossilli/glow/util"
+)
+
+// Signer signs messages with an account key. Any Signer is also a
+// crypto.Signer, so it can sign Flow transactions directly.
+type Signer interface {
+	// Sign hashes the message with HashAlgorithm and signs the hash.
+	Sign(message []byte) ([]byte, error)
+	PublicKey() crypto.PublicKey
+	HashAlgorithm() crypto.HashAlgorithm
+}
+
+// cryptoSigner adapts a crypto.Signer, which does not report its hash algorithm.
+type cryptoSigner struct {
+	crypto.Signer
+	hashAlgo crypto.HashAlgorithm
+}
+
+func (s cryptoSigner) HashAlgorithm() crypto.HashAlgorithm {
+	return s.hashAlgo
+}
+
+// FromCrypto adapts a crypto.Signer, such as one of the SDK's KMS signers,
+// that hashes with hashAlgo.
+func FromCrypto(s crypto.Signer, hashAlgo crypto.HashAlgorithm) Signer {
+	return cryptoSigner{Signer: s, hashAlgo: hashAlgo}
+}
+
+// NewInMemory creates a signer for a hex private key held in memory. This
+// is the signer of keys given in flow.json.
+func NewInMemory(privKey string, sigAlgo crypto.SignatureAlgorithm, hashAlgo crypto.HashAlgorithm) (Signer, error) {
+	pk, err := crypto.DecodePrivateKeyHex(sigAlgo, util.RemoveHexPrefix(privKey))
+	if err != nil {
+		return nil, err
+	}
+	return NewFromPrivateKey(pk, hashAlgo)
+}
+
+// NewFromPrivateKey creates an in-memory signer for a decoded private key.
+func NewFromPrivateKey(pk crypto.PrivateKey, hashAlgo crypto.HashAlgorithm) (Signer, error) {
+	s, err := crypto.NewInMemorySigner(pk, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+	return FromCrypto(s, hashAlgo), nil
+}
+
+// NewFile creates a signer for a file holding a hex private key, the
+// format of the Flow CLI's file keys.
+func NewFile(path string, sigAlgo crypto.SignatureAlgorithm, hashAlgo crypto.HashAlgorithm) (Signer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewInMemory(strings.TrimSpace(string(b)), sigAlgo, hashAlgo)
+	if err != nil {
+		return nil, fmt.Errorf("key file %s: %w", path, err)
+	}
+	return s, nil
+}
@@ -0,0 +1,169 @@
+package signer
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// Methods of the socket protocol. Each request and response is one line
+// of JSON: {"method": "sign", "message": "<hex>"} is answered with
+// {"signature": "<hex>"}, and {"method": "publicKey"} with {"publicKey":
+// "<hex>", "signatureAlgorithm": "ECDSA_P256", "hashAlgorithm": "SHA3_256"}.
+// A failed request is answered with {"error": "<message>"}.
+const (
+	SOCKET_SIGN       = "sign"
+	SOCKET_PUBLIC_KEY = "publicKey"
+)
+
+type socketRequest struct {
+	Method  string `json:"method"`
+	Message string `json:"message,omitempty"`
+}
+
+type socketResponse struct {
+	Signature string `json:"signature,omitempty"`
+	PublicKey string `json:"publicKey,omitempty"`
+	SigAlgo   string `json:"signatureAlgorithm,omitempty"`
+	HashAlgo  string `json:"hashAlgorithm,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// socketSigner signs by asking an external process listening on a unix socket.
+type socketSigner struct {
+	path      string
+	publicKey crypto.PublicKey
+	hashAlgo  crypto.HashAlgorithm
+}
+
+// NewSocket creates a signer for an external signing process listening on
+// the unix socket at path. The process holds the key; it is asked for the
+// public key once, when the signer is created, and to sign each message.
+// See ServeSocket for the protocol.
+func NewSocket(path string) (Signer, error) {
+	s := &socketSigner{path: path}
+
+	res, err := s.call(socketRequest{Method: SOCKET_PUBLIC_KEY})
+	if err != nil {
+		return nil, err
+	}
+	sigAlgo := crypto.StringToSignatureAlgorithm(res.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("socket %s: unknown signature algorithm %q", path, res.SigAlgo)
+	}
+	s.hashAlgo = crypto.StringToHashAlgorithm(res.HashAlgo)
+	if s.hashAlgo == crypto.UnknownHashAlgorithm {
+		return nil, fmt.Errorf("socket %s: unknown hash algorithm %q", path, res.HashAlgo)
+	}
+	s.publicKey, err = crypto.DecodePublicKeyHex(sigAlgo, res.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("socket %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+func (s *socketSigner) Sign(message []byte) ([]byte, error) {
+	res, err := s.call(socketRequest{Method: SOCKET_SIGN, Message: hex.EncodeToString(message)})
+	if err != nil {
+		return nil, err
+	}
+	sig, err := hex.DecodeString(res.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("socket %s: signature: %w", s.path, err)
+	}
+	return sig, nil
+}
+
+func (s *socketSigner) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
+
+func (s *socketSigner) HashAlgorithm() crypto.HashAlgorithm {
+	return s.hashAlgo
+}
+
+// call sends one request on a new connection and reads the response.
+func (s *socketSigner) call(req socketRequest) (socketResponse, error) {
+	var res socketResponse
+
+	conn, err := net.Dial("unix", s.path)
+	if err != nil {
+		return res, fmt.Errorf("socket %s: %w", s.path, err)
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return res, fmt.Errorf("socket %s: %w", s.path, err)
+	}
+	if err := json.NewDecoder(conn).Decode(&res); err != nil {
+		return res, fmt.Errorf("socket %s: %w", s.path, err)
+	}
+	if res.Error != "" {
+		return res, fmt.Errorf("socket %s: %s", s.path, res.Error)
+	}
+	return res, nil
+}
+
+// ServeSocket answers the requests of socket signers on l with s until l
+// is closed. It is a reference implementation of the external process's
+// side of the protocol, for signing processes written in Go.
+func ServeSocket(l net.Listener, s Signer) error {
+	for {
+		conn, err := l.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		go serveSocketConn(conn, s)
+	}
+}
+
+func serveSocketConn(conn net.Conn, s Signer) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	enc := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var req socketRequest
+		var res socketResponse
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			res.Error = err.Error()
+			enc.Encode(res)
+			continue
+		}
+
+		switch req.Method {
+		case SOCKET_PUBLIC_KEY:
+			pub := s.PublicKey()
+			res.PublicKey = hex.EncodeToString(pub.Encode())
+			res.SigAlgo = pub.Algorithm().String()
+			res.HashAlgo = s.HashAlgorithm().String()
+		case SOCKET_SIGN:
+			message, err := hex.DecodeString(req.Message)
+			if err != nil {
+				res.Error = fmt.Sprintf("message: %v", err)
+				break
+			}
+			sig, err := s.Sign(message)
+			if err != nil {
+				res.Error = err.Error()
+				break
+			}
+			res.Signature = hex.EncodeToString(sig)
+		default:
+			res.Error = fmt.Sprintf("unknown method %q", req.Method)
+		}
+
+		if err := enc.Encode(res); err != nil {
+			return
+		}
+	}
+}
@@ -27,21 +27,103 @@ const (
 
 	// Creates a new Flow account with a specified public key.
 	TX_CREATE_ACCOUNT = `
-	transaction(publicKey: String) {
+	transaction(publicKey: String, signatureAlgorithm: UInt8, hashAlgorithm: UInt8, weight: UFix64) {
 		prepare(signer: AuthAccount) {
 			let account = AuthAccount(payer: signer)
 			let key = PublicKey(
 				publicKey: publicKey.decodeHex(),
-				signatureAlgorithm: SignatureAlgorithm.ECDSA_P256
+				signatureAlgorithm: SignatureAlgorithm(rawValue: signatureAlgorithm)!
 			)
 			account.keys.add(
 				publicKey: key,
-				hashAlgorithm: HashAlgorithm.SHA3_256,
-				weight: 1000.0
+				hashAlgorithm: HashAlgorithm(rawValue: hashAlgorithm)!,
+				weight: weight
 			)
 		}
 	}`
 
+	// Creates an account with a key per element of the argument arrays.
+	// Algorithms are given as the raw values of Cadence's
+	// SignatureAlgorithm and HashAlgorithm enums.
+	TX_CREATE_ACCOUNT_WITH_KEYS = `
+	transaction(publicKeys: [String], signatureAlgorithms: [UInt8], hashAlgorithms: [UInt8], weights: [UFix64]) {
+		prepare(signer: AuthAccount) {
+			let account = AuthAccount(payer: signer)
+			var i = 0
+			while i < publicKeys.length {
+				account.keys.add(
+					publicKey: PublicKey(
+						publicKey: publicKeys[i].decodeHex(),
+						signatureAlgorithm: SignatureAlgorithm(rawValue: signatureAlgorithms[i])!
+					),
+					hashAlgorithm: HashAlgorithm(rawValue: hashAlgorithms[i])!,
+					weight: weights[i]
+				)
+				i = i + 1
+			}
+		}
+	}`
+
+	// Adds a key to the signer's account. Algorithms are given as raw values
+	// of Cadence's SignatureAlgorithm and HashAlgorithm enums.
+	TX_ACCOUNT_KEY_ADD = `
+	transaction(publicKey: String, signatureAlgorithm: UInt8, hashAlgorithm: UInt8, weight: UFix64) {
+		prepare(signer: AuthAccount) {
+			signer.keys.add(
+				publicKey: PublicKey(
+					publicKey: publicKey.decodeHex(),
+					signatureAlgorithm: SignatureAlgorithm(rawValue: signatureAlgorithm)!
+				),
+				hashAlgorithm: HashAlgorithm(rawValue: hashAlgorithm)!,
+				weight: weight
+			)
+		}
+	}`
+
+	// Adds count copies of a key to the signer's account, e.g. to propose
+	// transactions concurrently with.
+	TX_ACCOUNT_KEY_ADD_COPIES = `
+	transaction(publicKey: String, signatureAlgorithm: UInt8, hashAlgorithm: UInt8, weight: UFix64, count: Int) {
+		prepare(signer: AuthAccount) {
+			var i = 0
+			while i < count {
+				signer.keys.add(
+					publicKey: PublicKey(
+						publicKey: publicKey.decodeHex(),
+						signatureAlgorithm: SignatureAlgorithm(rawValue: signatureAlgorithm)!
+					),
+					hashAlgorithm: HashAlgorithm(rawValue: hashAlgorithm)!,
+					weight: weight
+				)
+				i = i + 1
+			}
+		}
+	}`
+
+	// Revokes a key of the signer's account.
+	TX_ACCOUNT_KEY_REVOKE = `
+	transaction(keyIndex: Int) {
+		prepare(signer: AuthAccount) {
+			signer.keys.revoke(keyIndex: keyIndex) ?? panic("No key at index ".concat(keyIndex.toString()))
+		}
+	}`
+
+	// Adds a key to the signer's account and revokes another in one transaction.
+	TX_ACCOUNT_KEY_ROTATE = `
+	transaction(publicKey: String, signatureAlgorithm: UInt8, hashAlgorithm: UInt8, weight: UFix64, revokeIndex: Int) {
+		prepare(signer: AuthAccount) {
+			signer.keys.add(
+				publicKey: PublicKey(
+					publicKey: publicKey.decodeHex(),
+					signatureAlgorithm: SignatureAlgorithm(rawValue: signatureAlgorithm)!
+				),
+				hashAlgorithm: HashAlgorithm(rawValue: hashAlgorithm)!,
+				weight: weight
+			)
+			signer.keys.revoke(keyIndex: revokeIndex) ?? panic("No key at index ".concat(revokeIndex.toString()))
+		}
+	}`
+
 	// Transfers Flow tokens from one account to another.
 	TX_FLOW_TRANSFER = `
 	import FungibleToken from 0xFungibleToken